@@ -2,4 +2,5 @@ package criteria
 
 type Criteria struct {
 	Filters Filters
+	Orders  []Order
 }
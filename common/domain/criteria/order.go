@@ -0,0 +1,14 @@
+package criteria
+
+// Order representa un criterio de ordenamiento; se aplican en el orden en que se declaran.
+type Order struct {
+	Field      FilterField `json:"field"`
+	Descending bool        `json:"descending"`
+}
+
+func NewOrder(field FilterField, descending bool) Order {
+	return Order{
+		Field:      field,
+		Descending: descending,
+	}
+}
@@ -3,6 +3,7 @@ package saga
 import (
 	"common/domain/customctx"
 	"common/utils"
+	"errors"
 	"reflect"
 )
 
@@ -17,10 +18,14 @@ type SAGA_Controller struct {
 	Steps    []SAGA_Step
 	Payloads map[string]utils.Result[Payload]
 	PrevSaga *SAGA_Controller
+
+	// RollbackErr junta los pasos que no se pudieron deshacer en el último Executed
+	RollbackErr error
 }
 
 func (c *SAGA_Controller) Executed(ctx *customctx.CustomContext) map[string]utils.Result[Payload] {
 	allPayloads := make(map[string]utils.Result[Payload])
+	c.RollbackErr = nil
 	var lastPayload utils.Result[Payload]
 	for _, step := range c.Steps {
 
@@ -41,24 +46,27 @@ func (c *SAGA_Controller) Executed(ctx *customctx.CustomContext) map[string]util
 		allPayloads[name_step] = result
 
 		if result.Err != nil {
-			c.Rollback(ctx)
+			rollbackErr := c.Rollback(ctx)
 			if c.PrevSaga != nil {
-				c.PrevSaga.Rollback(ctx)
+				rollbackErr = errors.Join(rollbackErr, c.PrevSaga.Rollback(ctx))
 			}
+			c.RollbackErr = rollbackErr
 			break
 		}
 	}
 	return allPayloads
 }
 
+// Rollback deshace todos los pasos en orden inverso; si alguno falla sigue con los demás
+// y devuelve los errores juntos
 func (c SAGA_Controller) Rollback(ctx *customctx.CustomContext) error {
+	var errs []error
 	for i := len(c.Steps) - 1; i >= 0; i-- {
-		err := c.Steps[i].Rollback(ctx)
-		if err != nil {
-			return err
+		if err := c.Steps[i].Rollback(ctx); err != nil {
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (c SAGA_Controller) Ok() bool {
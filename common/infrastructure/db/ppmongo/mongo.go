@@ -250,21 +250,7 @@ func (m *MongoRepository[T, L]) FindAll(ctx context.Context) utils.Result[[]L] {
 
 func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string, offset int, limit int) utils.Result[[]L] {
 	// Construir el filtro BSON basado en los criterios
-	filter := bson.M{}
-
-	// Recorrer los filtros para construir el filtro BSON
-	for _, f := range cr.Filters.Get() {
-		// Convertir el operador SQL a operador MongoDB
-		mongoOperator := convertSQLOperatorToMongo(f.Operator, f.Value)
-
-		// Aplicar el filtro al campo correspondiente
-		if mongoOperator != nil {
-			filter[string(f.Field)] = mongoOperator
-		} else {
-			// Para operadores simples como igualdad
-			filter[string(f.Field)] = f.Value
-		}
-	}
+	filter := matchingFilter(cr)
 
 	// Configurar opciones de búsqueda con paginación
 	opts := options.Find()
@@ -275,6 +261,20 @@ func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string
 		opts.SetLimit(int64(limit))
 	}
 
+	// Ordenamiento; _id al final para que la paginación sea estable
+	if len(cr.Orders) > 0 {
+		sort := bson.D{}
+		for _, o := range cr.Orders {
+			direction := 1
+			if o.Descending {
+				direction = -1
+			}
+			sort = append(sort, bson.E{Key: string(o.Field), Value: direction})
+		}
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+		opts.SetSort(sort)
+	}
+
 	// Ejecutar la consulta
 	cursor, err := m.Collection.Find(context.Background(), filter, opts)
 	if err != nil {
@@ -291,6 +291,44 @@ func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string
 	return utils.Result[[]L]{Data: entities}
 }
 
+// CountMatching cuenta los documentos que cumplen los filtros (sin paginación)
+func (m *MongoRepository[T, L]) CountMatching(cr criteria.Criteria, table_name string) utils.Result[int64] {
+	count, err := m.Collection.CountDocuments(context.Background(), matchingFilter(cr))
+	if err != nil {
+		return utils.Result[int64]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.count_matching")}
+	}
+	return utils.Result[int64]{Data: count}
+}
+
+// matchingFilter construye el filtro BSON; varios filtros sobre el mismo campo
+// (p. ej. un rango de fechas) se combinan con $and en lugar de reemplazarse
+func matchingFilter(cr criteria.Criteria) bson.M {
+	conditions := bson.A{}
+
+	// Recorrer los filtros para construir el filtro BSON
+	for _, f := range cr.Filters.Get() {
+		// Convertir el operador SQL a operador MongoDB
+		mongoOperator := convertSQLOperatorToMongo(f.Operator, f.Value)
+
+		// Aplicar el filtro al campo correspondiente
+		if mongoOperator != nil {
+			conditions = append(conditions, bson.M{string(f.Field): mongoOperator})
+		} else {
+			// Para operadores simples como igualdad
+			conditions = append(conditions, bson.M{string(f.Field): f.Value})
+		}
+	}
+
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0].(bson.M)
+	default:
+		return bson.M{"$and": conditions}
+	}
+}
+
 // convertSQLOperatorToMongo convierte operadores SQL a operadores MongoDB
 func convertSQLOperatorToMongo(operator criteria.Operator, value interface{}) interface{} {
 	switch operator {
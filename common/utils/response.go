@@ -22,16 +22,26 @@ type Alert struct {
 	Scope   string `json:"scope,omitempty"`
 }
 
+// Pagination acompaña a Results cuando el listado está paginado
+type Pagination struct {
+	Total  int64  `json:"total"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Next   string `json:"next,omitempty"`
+	Prev   string `json:"prev,omitempty"`
+}
+
 type Response[R any] struct {
 	Error      cerrs.CustomErrorInterface `json:"error,omitempty"`
 	StatusCode int                        `json:"status_code" default:"200"`
 
-	Data    R                            `json:"data,omitempty"`
-	Results []R                          `json:"results,omitempty"`
-	Alert   *Alert                       `json:"alert,omitempty"`
-	TraceID string                       `json:"trace_id,omitempty"`
-	Success bool                         `json:"success" default:"true"`
-	Errors  []cerrs.CustomErrorInterface `json:"errors,omitempty"`
+	Data       R                            `json:"data,omitempty"`
+	Results    []R                          `json:"results,omitempty"`
+	Pagination *Pagination                  `json:"pagination,omitempty"`
+	Alert      *Alert                       `json:"alert,omitempty"`
+	TraceID    string                       `json:"trace_id,omitempty"`
+	Success    bool                         `json:"success" default:"true"`
+	Errors     []cerrs.CustomErrorInterface `json:"errors,omitempty"`
 }
 
 func (r Response[R]) ToMapWithCustomContext(ctx *customctx.CustomContext) map[string]interface{} {
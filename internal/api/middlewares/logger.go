@@ -8,10 +8,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// ReceivedAtKey guarda en el gin.Context el momento en que llegó la petición.
+const ReceivedAtKey = "received_at"
+
 // RequestLoggerMiddleware crea un logrus.Entry con los campos de la petición y lo guarda en el contexto.
 func LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
+		c.Set(ReceivedAtKey, startTime)
 
 		requestID := uuid.New().String()
 
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/api/v1/datasets/domain/commands"
+	"mocky/internal/db/mongo/datasets"
+	"net/http"
+)
+
+func (s *DatasetsService) Create(cc *customctx.CustomContext, dataset commands.CreateDatasetCommand) utils.Response[datasets.DatasetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Creating dataset")
+
+	datasetEntity := dataset.ToEntity()
+
+	datasetModel := datasets.DatasetModel{
+		Name:    datasetEntity.Name,
+		Records: datasetEntity.Records,
+		Size:    len(datasetEntity.Records),
+	}
+
+	result := s.datasetsRepository.SaveOrUpdate(cc, datasetModel)
+	if result.Err != nil {
+		entry.Error(result.Err.Error())
+		return utils.Response[datasets.DatasetModel]{
+			Error:      result.Err,
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+	}
+
+	datasetModel.ID = result.Data
+
+	return utils.Response[datasets.DatasetModel]{
+		Data:       datasetModel,
+		StatusCode: http.StatusCreated,
+		Success:    true,
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"net/http"
+)
+
+func (s *DatasetsService) Delete(cc *customctx.CustomContext, name string) utils.Response[string] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Deleting dataset")
+
+	if err := s.datasetsRepository.DeleteByName(cc, name); err != nil {
+		entry.Error(err.Error())
+		code := http.StatusInternalServerError
+		if customErr, ok := err.(cerrs.CustomErrorInterface); ok {
+			code = customErr.GetCode()
+		}
+		return utils.Response[string]{
+			Error:      cerrs.NewCustomError(code, err.Error(), "datasets.delete"),
+			StatusCode: code,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[string]{
+		Data:       name,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
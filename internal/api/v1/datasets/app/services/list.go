@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/datasets"
+	"net/http"
+)
+
+func (s *DatasetsService) List(cc *customctx.CustomContext) utils.Response[datasets.DatasetListModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Listing datasets")
+
+	datasetsList := s.datasetsRepository.FindAll(cc.Context())
+	if datasetsList.Err != nil {
+		return utils.Response[datasets.DatasetListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Error:      datasetsList.Err,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[datasets.DatasetListModel]{
+		StatusCode: http.StatusOK,
+		Results:    datasetsList.Data,
+		Success:    true,
+	}
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"context"
+)
+
+// Records expone los registros de un dataset a los templates ({{dataset.users.random}})
+func (s *DatasetsService) Records(ctx context.Context, name string) ([]map[string]any, bool) {
+	dataset := s.datasetsRepository.GetByName(customctx.NewCustomContext(ctx), name)
+	if dataset.Err != nil {
+		return nil, false
+	}
+	return dataset.Data.Records, true
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/datasets"
+	"net/http"
+)
+
+func (s *DatasetsService) Retrieve(cc *customctx.CustomContext, name string) utils.Response[datasets.DatasetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving dataset")
+
+	dataset := s.datasetsRepository.GetByName(cc, name)
+	if dataset.Err != nil {
+		return utils.Response[datasets.DatasetModel]{
+			Error:      dataset.Err,
+			StatusCode: dataset.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	return utils.Response[datasets.DatasetModel]{
+		StatusCode: http.StatusOK,
+		Data:       dataset.Data,
+		Success:    true,
+	}
+}
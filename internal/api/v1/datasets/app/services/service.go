@@ -0,0 +1,17 @@
+package services
+
+import (
+	"mocky/internal/api/v1/datasets/domain/repositories"
+)
+
+type DatasetsService struct {
+	datasetsRepository repositories.RepositoryDatasets
+}
+
+func NewDatasetsService(
+	datasetsRepository repositories.RepositoryDatasets,
+) *DatasetsService {
+	return &DatasetsService{
+		datasetsRepository: datasetsRepository,
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"mocky/internal/api/v1/datasets/domain/commands"
+	"mocky/internal/db/mongo/datasets"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Upload crea (o reemplaza) un dataset a partir de un archivo CSV o JSON (array de objetos)
+func (s *DatasetsService) Upload(cc *customctx.CustomContext, name string, file *multipart.FileHeader) utils.Response[datasets.DatasetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Uploading dataset %s from %s", name, file.Filename)
+
+	records, err := _readRecords(file)
+	if err != nil {
+		entry.Error(err.Error())
+		return utils.Response[datasets.DatasetModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusBadRequest, err.Error(), "datasets.upload.read_records")),
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+		}
+	}
+
+	return s.Create(cc, commands.CreateDatasetCommand{Name: name, Records: records})
+}
+
+func _readRecords(file *multipart.FileHeader) ([]map[string]any, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := file.Header.Get("Content-Type")
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+
+	switch {
+	case ext == ".json" || strings.Contains(contentType, "json"):
+		return _parseJSONRecords(data)
+	case ext == ".csv" || strings.Contains(contentType, "csv"):
+		return _parseCSVRecords(data)
+	}
+
+	// sin pista del tipo: intentamos JSON y luego CSV
+	if records, err := _parseJSONRecords(data); err == nil {
+		return records, nil
+	}
+	return _parseCSVRecords(data)
+}
+
+func _parseJSONRecords(data []byte) ([]map[string]any, error) {
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.New("json dataset must be an array of objects: " + err.Error())
+	}
+	return records, nil
+}
+
+// _parseCSVRecords usa la primera fila como encabezados; los valores quedan como string
+func _parseCSVRecords(data []byte) ([]map[string]any, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid csv dataset: " + err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("csv dataset must have a header row")
+	}
+
+	headers := rows[0]
+	records := make([]map[string]any, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]any, len(headers))
+		for i, header := range headers {
+			if i < len(row) {
+				record[header] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
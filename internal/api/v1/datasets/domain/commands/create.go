@@ -0,0 +1,19 @@
+package commands
+
+import "mocky/internal/api/v1/datasets/domain/entities"
+
+type CreateDatasetCommand struct {
+	Name    string           `json:"name" binding:"required"`
+	Records []map[string]any `json:"records" binding:"required"`
+}
+
+func (c CreateDatasetCommand) Validate() error {
+	return nil
+}
+
+func (c CreateDatasetCommand) ToEntity() entities.DatasetEntity {
+	return entities.DatasetEntity{
+		Name:    c.Name,
+		Records: c.Records,
+	}
+}
@@ -0,0 +1,6 @@
+package entities
+
+type DatasetEntity struct {
+	Name    string           `json:"name" binding:"required"`
+	Records []map[string]any `json:"records" binding:"required"`
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"context"
+	"mocky/internal/db/mongo/datasets"
+)
+
+type RepositoryDatasets interface {
+	Find(ctx context.Context, id string) utils.Result[datasets.DatasetModel]
+	FindAll(ctx context.Context) utils.Result[[]datasets.DatasetListModel]
+
+	GetByName(cc *customctx.CustomContext, name string) utils.Result[datasets.DatasetModel]
+	SaveOrUpdate(cc *customctx.CustomContext, document datasets.DatasetModel) utils.Result[string]
+	DeleteByName(cc *customctx.CustomContext, name string) error
+}
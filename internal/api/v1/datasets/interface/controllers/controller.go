@@ -0,0 +1,13 @@
+package controllers
+
+import "mocky/internal/api/v1/datasets/app/services"
+
+type DatasetsController struct {
+	datasetsService *services.DatasetsService
+}
+
+func NewDatasetsController(datasetsService *services.DatasetsService) *DatasetsController {
+	return &DatasetsController{
+		datasetsService: datasetsService,
+	}
+}
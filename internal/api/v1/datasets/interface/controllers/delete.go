@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *DatasetsController) Delete(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Deleting dataset")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.datasetsService.Delete(cc, ctx.Param("name"))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
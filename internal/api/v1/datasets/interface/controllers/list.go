@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *DatasetsController) List(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("List datasets")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	datasets := c.datasetsService.List(cc)
+
+	ctx.JSON(datasets.StatusCode, datasets.ToMapWithCustomContext(cc))
+}
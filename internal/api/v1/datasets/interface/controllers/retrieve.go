@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *DatasetsController) Retrieve(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Retrieving dataset")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dataset := c.datasetsService.Retrieve(cc, ctx.Param("name"))
+
+	ctx.JSON(dataset.StatusCode, dataset.ToMapWithCustomContext(cc))
+}
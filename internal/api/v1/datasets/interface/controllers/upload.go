@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"common/utils/cerrs"
+	"mocky/internal/api/v1/datasets/interface/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *DatasetsController) Upload(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dto := cdtos.BindFormData[dtos.UploadDatasetDTO](ctx, cc)
+	if dto.Error != nil {
+		entry.Error(dto.Error.Error())
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	if err := dto.Data.Validate(); err != nil {
+		entry.Error(err.Error())
+		dto.Error = cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "dto.validate.UploadDatasetDTO"))
+		dto.StatusCode = http.StatusUnprocessableEntity
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.datasetsService.Upload(cc, dto.Data.Name, dto.Data.File)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"errors"
+	"mime/multipart"
+	"mocky/internal/api/v1/datasets/domain/commands"
+	"regexp"
+)
+
+// El nombre se usa en los templates ({{dataset.<name>.random}}), por eso no admite puntos
+var datasetNameRe = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+func validateDatasetName(name string) error {
+
+	if name == "" {
+		return errors.New("name is required")
+	}
+
+	if !datasetNameRe.MatchString(name) {
+		return errors.New("name must contain only letters, numbers, '_' or '-'")
+	}
+
+	return nil
+}
+
+type CreateDatasetDTO struct {
+	Name    string           `json:"name" binding:"required"`
+	Records []map[string]any `json:"records" binding:"required"`
+}
+
+func (dto CreateDatasetDTO) Validate() error {
+
+	if err := validateDatasetName(dto.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dto CreateDatasetDTO) ToCommand() commands.CreateDatasetCommand {
+	return commands.CreateDatasetCommand{
+		Name:    dto.Name,
+		Records: dto.Records,
+	}
+}
+
+// UploadDatasetDTO recibe un multipart/form-data con el nombre y un archivo .csv o .json
+type UploadDatasetDTO struct {
+	Name string                `form:"name" binding:"required"`
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+func (dto UploadDatasetDTO) Validate() error {
+
+	if err := validateDatasetName(dto.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package datasets
+
+import (
+	"mocky/internal/api/v1/datasets/app/services"
+	"mocky/internal/api/v1/datasets/interface/controllers"
+	"mocky/internal/core/settings"
+	datasets_inmemory "mocky/internal/db/inmemory/datasets"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupDatasetsModule registra las rutas de /v1/datasets y devuelve el servicio
+// para que los templates de prototypes puedan leer los registros.
+func SetupDatasetsModule(r *gin.Engine) *services.DatasetsService {
+
+	// repositories
+	// datasetsRepository := datasets.NewDatasetsMongoRepository(
+	// 	settings.Settings.MONGO_DSN,
+	// 	"mocky_db",
+	// 	"datasets",
+	// )
+
+	datasetsRepositoryInMemory := datasets_inmemory.NewInMemoryDatasetsRepository(15 * time.Minute)
+
+	// Services
+	datasetsService := services.NewDatasetsService(datasetsRepositoryInMemory)
+
+	// Controllers
+	datasetsController := controllers.NewDatasetsController(datasetsService)
+
+	// Routes
+	datasetsGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/datasets")
+	datasetsGroup.POST("", datasetsController.Create)
+	datasetsGroup.POST("/upload", datasetsController.Upload)
+	datasetsGroup.GET("", datasetsController.List)
+	datasetsGroup.GET("/:name", datasetsController.Retrieve)
+	datasetsGroup.DELETE("/:name", datasetsController.Delete)
+
+	return datasetsService
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"mocky/internal/api/v1/formats/domain/commands"
+	validator_controller "mocky/internal/context/controllers"
+	"mocky/internal/db/mongo/formats"
+	"net/http"
+)
+
+// Create registra (o reemplaza) un format. La regex y el checksum se verifican aquí,
+// no al validar cada request.
+func (s *FormatsService) Create(cc *customctx.CustomContext, format commands.CreateFormatCommand) utils.Response[formats.FormatModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Creating format")
+
+	formatEntity := format.ToEntity()
+
+	if validator_controller.IsBuiltinFormat(formatEntity.Name) {
+		return utils.Response[formats.FormatModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusConflict, "format "+formatEntity.Name+" is built-in and can't be replaced", "formats.create.builtin")),
+			StatusCode: http.StatusConflict,
+			Success:    false,
+		}
+	}
+
+	if _, err := validator_controller.CompileFormat(formatEntity.Pattern, formatEntity.Checksum); err != nil {
+		return utils.Response[formats.FormatModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "formats.create.compile")),
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+		}
+	}
+
+	formatModel := formats.FormatModel{
+		Name:     formatEntity.Name,
+		Pattern:  formatEntity.Pattern,
+		Checksum: formatEntity.Checksum,
+	}
+
+	result := s.formatsRepository.SaveOrUpdate(cc, formatModel)
+	if result.Err != nil {
+		entry.Error(result.Err.Error())
+		return utils.Response[formats.FormatModel]{
+			Error:      result.Err,
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+	}
+
+	formatModel.ID = result.Data
+	s.checkers.Delete(formatModel.Name)
+
+	return utils.Response[formats.FormatModel]{
+		Data:       formatModel,
+		StatusCode: http.StatusCreated,
+		Success:    true,
+	}
+}
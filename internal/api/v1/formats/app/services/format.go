@@ -0,0 +1,38 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"context"
+	validator_controller "mocky/internal/context/controllers"
+)
+
+// compiledFormat es el checker de un format junto con lo que se usó para compilarlo
+type compiledFormat struct {
+	pattern  string
+	checksum string
+	checker  validator_controller.FormatChecker
+}
+
+// CustomFormat expone los formats registrados al validador del bodySchema. El format se
+// busca siempre en el repositorio (uno borrado o caducado deja de existir), pero la regex
+// solo se compila cuando cambia.
+func (s *FormatsService) CustomFormat(name string) (validator_controller.FormatChecker, bool) {
+	format := s.formatsRepository.GetByName(customctx.NewCustomContext(context.Background()), name)
+	if format.Err != nil {
+		s.checkers.Delete(name)
+		return nil, false
+	}
+
+	if cached, ok := s.checkers.Load(name); ok {
+		if c := cached.(*compiledFormat); c.pattern == format.Data.Pattern && c.checksum == format.Data.Checksum {
+			return c.checker, true
+		}
+	}
+
+	checker, err := validator_controller.CompileFormat(format.Data.Pattern, format.Data.Checksum)
+	if err != nil {
+		return nil, false
+	}
+	s.checkers.Store(name, &compiledFormat{pattern: format.Data.Pattern, checksum: format.Data.Checksum, checker: checker})
+	return checker, true
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/formats"
+	"net/http"
+)
+
+func (s *FormatsService) List(cc *customctx.CustomContext) utils.Response[formats.FormatListModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Listing formats")
+
+	formatsList := s.formatsRepository.FindAll(cc.Context())
+	if formatsList.Err != nil {
+		return utils.Response[formats.FormatListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Error:      formatsList.Err,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[formats.FormatListModel]{
+		StatusCode: http.StatusOK,
+		Results:    formatsList.Data,
+		Success:    true,
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/formats"
+	"net/http"
+)
+
+func (s *FormatsService) Retrieve(cc *customctx.CustomContext, name string) utils.Response[formats.FormatModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving format")
+
+	format := s.formatsRepository.GetByName(cc, name)
+	if format.Err != nil {
+		return utils.Response[formats.FormatModel]{
+			Error:      format.Err,
+			StatusCode: format.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	return utils.Response[formats.FormatModel]{
+		StatusCode: http.StatusOK,
+		Data:       format.Data,
+		Success:    true,
+	}
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"mocky/internal/api/v1/formats/domain/repositories"
+	"sync"
+)
+
+type FormatsService struct {
+	formatsRepository repositories.RepositoryFormats
+
+	// checkers compilados por nombre (ver CustomFormat)
+	checkers sync.Map
+}
+
+func NewFormatsService(
+	formatsRepository repositories.RepositoryFormats,
+) *FormatsService {
+	return &FormatsService{
+		formatsRepository: formatsRepository,
+	}
+}
@@ -0,0 +1,21 @@
+package commands
+
+import "mocky/internal/api/v1/formats/domain/entities"
+
+type CreateFormatCommand struct {
+	Name     string `json:"name" binding:"required"`
+	Pattern  string `json:"pattern"`
+	Checksum string `json:"checksum"`
+}
+
+func (c CreateFormatCommand) Validate() error {
+	return nil
+}
+
+func (c CreateFormatCommand) ToEntity() entities.FormatEntity {
+	return entities.FormatEntity{
+		Name:     c.Name,
+		Pattern:  c.Pattern,
+		Checksum: c.Checksum,
+	}
+}
@@ -0,0 +1,9 @@
+package entities
+
+// FormatEntity es un format personalizado: una regex, una regla de checksum
+// (luhn, mod97, clabe) o ambas.
+type FormatEntity struct {
+	Name     string `json:"name" binding:"required"`
+	Pattern  string `json:"pattern"`
+	Checksum string `json:"checksum"`
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"context"
+	"mocky/internal/db/mongo/formats"
+)
+
+type RepositoryFormats interface {
+	Find(ctx context.Context, id string) utils.Result[formats.FormatModel]
+	FindAll(ctx context.Context) utils.Result[[]formats.FormatListModel]
+
+	GetByName(cc *customctx.CustomContext, name string) utils.Result[formats.FormatModel]
+	SaveOrUpdate(cc *customctx.CustomContext, document formats.FormatModel) utils.Result[string]
+	DeleteByName(cc *customctx.CustomContext, name string) error
+}
@@ -0,0 +1,13 @@
+package controllers
+
+import "mocky/internal/api/v1/formats/app/services"
+
+type FormatsController struct {
+	formatsService *services.FormatsService
+}
+
+func NewFormatsController(formatsService *services.FormatsService) *FormatsController {
+	return &FormatsController{
+		formatsService: formatsService,
+	}
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormatsController) List(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("List formats")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	formats := c.formatsService.List(cc)
+
+	ctx.JSON(formats.StatusCode, formats.ToMapWithCustomContext(cc))
+}
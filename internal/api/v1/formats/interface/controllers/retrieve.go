@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormatsController) Retrieve(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Retrieving format")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	format := c.formatsService.Retrieve(cc, ctx.Param("name"))
+
+	ctx.JSON(format.StatusCode, format.ToMapWithCustomContext(cc))
+}
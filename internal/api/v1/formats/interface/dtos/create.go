@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"errors"
+	"mocky/internal/api/v1/formats/domain/commands"
+	"regexp"
+	"strings"
+)
+
+var formatNameRe = regexp.MustCompile(`^[a-z0-9_\-\.]+$`)
+
+type CreateFormatDTO struct {
+	Name     string `json:"name" binding:"required"`
+	Pattern  string `json:"pattern"`
+	Checksum string `json:"checksum"`
+}
+
+func (dto CreateFormatDTO) Validate() error {
+
+	if dto.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if !formatNameRe.MatchString(strings.ToLower(dto.Name)) {
+		return errors.New("name must contain only letters, numbers, '_', '-' or '.'")
+	}
+
+	if dto.Pattern == "" && dto.Checksum == "" {
+		return errors.New("pattern or checksum is required")
+	}
+
+	return nil
+}
+
+// ToCommand normaliza el nombre: los formats se comparan sin distinguir mayúsculas
+func (dto CreateFormatDTO) ToCommand() commands.CreateFormatCommand {
+	return commands.CreateFormatCommand{
+		Name:     strings.ToLower(dto.Name),
+		Pattern:  dto.Pattern,
+		Checksum: dto.Checksum,
+	}
+}
@@ -0,0 +1,40 @@
+package formats
+
+import (
+	"mocky/internal/api/v1/formats/app/services"
+	"mocky/internal/api/v1/formats/interface/controllers"
+	"mocky/internal/core/settings"
+	formats_inmemory "mocky/internal/db/inmemory/formats"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupFormatsModule registra las rutas de /v1/formats y devuelve el servicio
+// para que el validador de prototypes pueda usar los formats personalizados.
+func SetupFormatsModule(r *gin.Engine) *services.FormatsService {
+
+	// repositories
+	// formatsRepository := formats.NewFormatsMongoRepository(
+	// 	settings.Settings.MONGO_DSN,
+	// 	"mocky_db",
+	// 	"formats",
+	// )
+
+	formatsRepositoryInMemory := formats_inmemory.NewInMemoryFormatsRepository(15 * time.Minute)
+
+	// Services
+	formatsService := services.NewFormatsService(formatsRepositoryInMemory)
+
+	// Controllers
+	formatsController := controllers.NewFormatsController(formatsService)
+
+	// Routes
+	formatsGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/formats")
+	formatsGroup.POST("", formatsController.Create)
+	formatsGroup.GET("", formatsController.List)
+	formatsGroup.GET("/:name", formatsController.Retrieve)
+	formatsGroup.DELETE("/:name", formatsController.Delete)
+
+	return formatsService
+}
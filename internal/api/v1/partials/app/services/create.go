@@ -0,0 +1,44 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/api/v1/partials/domain/commands"
+	"mocky/internal/db/mongo/partials"
+	"net/http"
+)
+
+// Create crea o reemplaza un partial. Como los partials se resuelven al responder,
+// el cambio aplica de inmediato a todos los prototypes que lo usan.
+func (s *PartialsService) Create(cc *customctx.CustomContext, partial commands.CreatePartialCommand) utils.Response[partials.PartialModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Creating partial")
+
+	partialEntity := partial.ToEntity()
+
+	partialModel := partials.PartialModel{
+		Name: partialEntity.Name,
+		Body: partialEntity.Body,
+	}
+
+	result := s.partialsRepository.SaveOrUpdate(cc, partialModel)
+	if result.Err != nil {
+		entry.Error(result.Err.Error())
+		return utils.Response[partials.PartialModel]{
+			Error:      result.Err,
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+	}
+
+	partialModel.ID = result.Data
+
+	return utils.Response[partials.PartialModel]{
+		Data:       partialModel,
+		StatusCode: http.StatusCreated,
+		Success:    true,
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/partials"
+	"net/http"
+)
+
+func (s *PartialsService) List(cc *customctx.CustomContext) utils.Response[partials.PartialListModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Listing partials")
+
+	partialsList := s.partialsRepository.FindAll(cc.Context())
+	if partialsList.Err != nil {
+		return utils.Response[partials.PartialListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Error:      partialsList.Err,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[partials.PartialListModel]{
+		StatusCode: http.StatusOK,
+		Results:    partialsList.Data,
+		Success:    true,
+	}
+}
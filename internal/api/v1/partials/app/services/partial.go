@@ -0,0 +1,15 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"context"
+)
+
+// Partial expone el body de un partial a los templates ({"$partial": "envelope"} / {{> envelope}})
+func (s *PartialsService) Partial(ctx context.Context, name string) (any, bool) {
+	partial := s.partialsRepository.GetByName(customctx.NewCustomContext(ctx), name)
+	if partial.Err != nil {
+		return nil, false
+	}
+	return partial.Data.Body, true
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/partials"
+	"net/http"
+)
+
+func (s *PartialsService) Retrieve(cc *customctx.CustomContext, name string) utils.Response[partials.PartialModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving partial")
+
+	partial := s.partialsRepository.GetByName(cc, name)
+	if partial.Err != nil {
+		return utils.Response[partials.PartialModel]{
+			Error:      partial.Err,
+			StatusCode: partial.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	return utils.Response[partials.PartialModel]{
+		StatusCode: http.StatusOK,
+		Data:       partial.Data,
+		Success:    true,
+	}
+}
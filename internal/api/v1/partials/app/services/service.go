@@ -0,0 +1,17 @@
+package services
+
+import (
+	"mocky/internal/api/v1/partials/domain/repositories"
+)
+
+type PartialsService struct {
+	partialsRepository repositories.RepositoryPartials
+}
+
+func NewPartialsService(
+	partialsRepository repositories.RepositoryPartials,
+) *PartialsService {
+	return &PartialsService{
+		partialsRepository: partialsRepository,
+	}
+}
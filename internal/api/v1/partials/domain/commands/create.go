@@ -0,0 +1,19 @@
+package commands
+
+import "mocky/internal/api/v1/partials/domain/entities"
+
+type CreatePartialCommand struct {
+	Name string `json:"name" binding:"required"`
+	Body any    `json:"body" binding:"required"`
+}
+
+func (c CreatePartialCommand) Validate() error {
+	return nil
+}
+
+func (c CreatePartialCommand) ToEntity() entities.PartialEntity {
+	return entities.PartialEntity{
+		Name: c.Name,
+		Body: c.Body,
+	}
+}
@@ -0,0 +1,8 @@
+package entities
+
+// PartialEntity es un fragmento de respuesta con nombre. Body puede ser un objeto,
+// un array o un string con placeholders; los parámetros llegan como {{with.x}}.
+type PartialEntity struct {
+	Name string `json:"name" binding:"required"`
+	Body any    `json:"body" binding:"required"`
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"context"
+	"mocky/internal/db/mongo/partials"
+)
+
+type RepositoryPartials interface {
+	Find(ctx context.Context, id string) utils.Result[partials.PartialModel]
+	FindAll(ctx context.Context) utils.Result[[]partials.PartialListModel]
+
+	GetByName(cc *customctx.CustomContext, name string) utils.Result[partials.PartialModel]
+	SaveOrUpdate(cc *customctx.CustomContext, document partials.PartialModel) utils.Result[string]
+	DeleteByName(cc *customctx.CustomContext, name string) error
+}
@@ -0,0 +1,13 @@
+package controllers
+
+import "mocky/internal/api/v1/partials/app/services"
+
+type PartialsController struct {
+	partialsService *services.PartialsService
+}
+
+func NewPartialsController(partialsService *services.PartialsService) *PartialsController {
+	return &PartialsController{
+		partialsService: partialsService,
+	}
+}
@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"mocky/internal/api/v1/partials/interface/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PartialsController) Create(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dto := cdtos.GetDTOWithResponse[dtos.CreatePartialDTO](ctx, cc)
+	if dto.Error != nil {
+		entry.Error(dto.Error.Error())
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	command := dto.Data.ToCommand()
+
+	response := c.partialsService.Create(cc, command)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+
+}
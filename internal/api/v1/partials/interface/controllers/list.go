@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PartialsController) List(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("List partials")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	partials := c.partialsService.List(cc)
+
+	ctx.JSON(partials.StatusCode, partials.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PartialsController) Retrieve(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Retrieving partial")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	partial := c.partialsService.Retrieve(cc, ctx.Param("name"))
+
+	ctx.JSON(partial.StatusCode, partial.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,39 @@
+package dtos
+
+import (
+	"errors"
+	"mocky/internal/api/v1/partials/domain/commands"
+	"regexp"
+)
+
+// El nombre se usa en los templates ({{> envelope}}), por eso no admite espacios
+var partialNameRe = regexp.MustCompile(`^[A-Za-z0-9_\-\.]+$`)
+
+type CreatePartialDTO struct {
+	Name string `json:"name" binding:"required"`
+	Body any    `json:"body" binding:"required"`
+}
+
+func (dto CreatePartialDTO) Validate() error {
+
+	if dto.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if !partialNameRe.MatchString(dto.Name) {
+		return errors.New("name must contain only letters, numbers, '_', '-' or '.'")
+	}
+
+	if dto.Body == nil {
+		return errors.New("body is required")
+	}
+
+	return nil
+}
+
+func (dto CreatePartialDTO) ToCommand() commands.CreatePartialCommand {
+	return commands.CreatePartialCommand{
+		Name: dto.Name,
+		Body: dto.Body,
+	}
+}
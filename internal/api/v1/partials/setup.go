@@ -0,0 +1,40 @@
+package partials
+
+import (
+	"mocky/internal/api/v1/partials/app/services"
+	"mocky/internal/api/v1/partials/interface/controllers"
+	"mocky/internal/core/settings"
+	partials_inmemory "mocky/internal/db/inmemory/partials"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPartialsModule registra las rutas de /v1/partials y devuelve el servicio
+// para que los templates de prototypes puedan resolver los partials.
+func SetupPartialsModule(r *gin.Engine) *services.PartialsService {
+
+	// repositories
+	// partialsRepository := partials.NewPartialsMongoRepository(
+	// 	settings.Settings.MONGO_DSN,
+	// 	"mocky_db",
+	// 	"partials",
+	// )
+
+	partialsRepositoryInMemory := partials_inmemory.NewInMemoryPartialsRepository(15 * time.Minute)
+
+	// Services
+	partialsService := services.NewPartialsService(partialsRepositoryInMemory)
+
+	// Controllers
+	partialsController := controllers.NewPartialsController(partialsService)
+
+	// Routes
+	partialsGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/partials")
+	partialsGroup.POST("", partialsController.Create)
+	partialsGroup.GET("", partialsController.List)
+	partialsGroup.GET("/:name", partialsController.Retrieve)
+	partialsGroup.DELETE("/:name", partialsController.Delete)
+
+	return partialsService
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mocky/internal/context/controllers/placeholder"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Límite de memoria para multipart; el resto de los archivos va a disco temporal
+const maxMultipartMemory = 32 << 20
+
+// Prefijo de los atributos XML y llave del texto de un elemento con atributos o hijos
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
+func _convertBodyToMap(r *http.Request) (map[string]any, []byte, error) {
+	if r.Body == nil {
+		return map[string]any{}, nil, nil
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Body.Close()
+
+	if len(bodyBytes) == 0 {
+		return map[string]any{}, bodyBytes, nil
+	}
+
+	// Forms y XML; si no se pueden parsear se tratan como texto plano. El Content-Type
+	// declarado manda: un form nunca se interpreta como JSON, aunque el body lo parezca
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(bodyBytes)); err == nil {
+			return _formValuesToMap(values), bodyBytes, nil
+		}
+		return map[string]any{placeholder.RawBodyKey: string(bodyBytes)}, bodyBytes, nil
+	case mediaType == "multipart/form-data":
+		if body, err := _parseMultipartBody(bodyBytes, params["boundary"]); err == nil {
+			return body, bodyBytes, nil
+		}
+	case _isXMLMediaType(mediaType):
+		if body, err := _parseXMLBody(bodyBytes); err == nil {
+			return body, bodyBytes, nil
+		}
+	}
+
+	var body any
+	if err := json.Unmarshal(bodyBytes, &body); err == nil {
+		// retornamos directamente el JSON como map[string]any
+		if bodyMap, ok := body.(map[string]any); ok {
+			return bodyMap, bodyBytes, nil
+		}
+		// arrays y escalares JSON conservan su estructura: {"raw": [...]}
+		return map[string]any{placeholder.RawBodyKey: body}, bodyBytes, nil
+	}
+
+	// si no es JSON válido, lo devolvemos como {"raw": "..."}
+	return map[string]any{placeholder.RawBodyKey: string(bodyBytes)}, bodyBytes, nil
+}
+
+// _bodyInstance devuelve el body tal como llegó (array, escalar u objeto) para validarlo
+// con JSON Schema; un body vacío es null. Forms y XML siempre son objetos.
+func _bodyInstance(r *http.Request, bodyMap map[string]any, rawBody []byte) any {
+	trimmed := strings.TrimSpace(string(rawBody))
+	if trimmed == "" {
+		return nil
+	}
+	if _isTextOnlyBody(r) {
+		return bodyMap
+	}
+	if raw, wrapped := bodyMap[placeholder.RawBodyKey]; wrapped && !strings.HasPrefix(trimmed, "{") {
+		return raw
+	}
+	return bodyMap
+}
+
+// _isTextOnlyBody indica si el body llega como texto (forms, XML): sus valores son strings
+// y se convierten a los tipos del bodySchema antes de validar.
+func _isTextOnlyBody(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" || _isXMLMediaType(mediaType)
+}
+
+func _isXMLMediaType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// _formValuesToMap: un valor queda como string y los repetidos (?tag=a&tag=b) como array
+func _formValuesToMap(values map[string][]string) map[string]any {
+	body := make(map[string]any, len(values))
+	for key, list := range values {
+		if len(list) == 1 {
+			body[key] = list[0]
+			continue
+		}
+		items := make([]any, len(list))
+		for i, v := range list {
+			items[i] = v
+		}
+		body[key] = items
+	}
+	return body
+}
+
+// _parseMultipartBody agrega los campos de texto y, por cada archivo, sus metadatos:
+// {"name": "avatar.png", "size": 1024, "content_type": "image/png"}
+func _parseMultipartBody(bodyBytes []byte, boundary string) (map[string]any, error) {
+	if boundary == "" {
+		return nil, errors.New("multipart body without boundary")
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(bodyBytes), boundary).ReadForm(maxMultipartMemory)
+	if err != nil {
+		return nil, err
+	}
+	defer form.RemoveAll()
+
+	body := _formValuesToMap(form.Value)
+	for field, files := range form.File {
+		metadata := make([]any, len(files))
+		for i, file := range files {
+			metadata[i] = map[string]any{
+				"name":         file.Filename,
+				"size":         float64(file.Size),
+				"content_type": file.Header.Get("Content-Type"),
+			}
+		}
+		if len(metadata) == 1 {
+			body[field] = metadata[0]
+			continue
+		}
+		body[field] = metadata
+	}
+	return body, nil
+}
+
+// _parseXMLBody convierte <order id="1"><item>a</item><item>b</item></order> en
+// {"order": {"@id": "1", "item": ["a", "b"]}}
+func _parseXMLBody(bodyBytes []byte) (map[string]any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bodyBytes))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := _parseXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{start.Name.Local: value}, nil
+		}
+	}
+}
+
+// _parseXMLElement devuelve el texto de un elemento simple, o un objeto con sus
+// atributos (@attr), hijos (repetidos como array) y texto (#text)
+func _parseXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	node := map[string]any{}
+	for _, attr := range start.Attr {
+		node[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := _parseXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := node[name].(type) {
+			case nil:
+				node[name] = child
+			case []any:
+				node[name] = append(existing, child)
+			default:
+				node[name] = []any{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(node) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				node[xmlTextKey] = content
+			}
+			return node, nil
+		}
+	}
+}
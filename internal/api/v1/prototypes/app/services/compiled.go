@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	validator_controller "mocky/internal/context/controllers"
+	"mocky/internal/context/controllers/placeholder"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"time"
+)
+
+// compiledPrototype es la forma lista para servir de un prototype: regexes, validadores y
+// templates del body y de los headers se preparan una sola vez, al registrarlo, y no en cada request.
+type compiledPrototype struct {
+	updatedAt time.Time
+	request   *validator_controller.CompiledRequest
+	body      *placeholder.Template
+	headers   *placeholder.Template
+	contract  *validator_controller.CompiledContract // nil si la respuesta no declara schema
+}
+
+func (s *PrototypesService) compile(prototype prototypes.PrototypeModel) (*compiledPrototype, error) {
+	request, err := s.validator.Compile(prototype.Request)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := s.validator.CompileContract(prototype.Response)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledPrototype{
+		updatedAt: prototype.UpdatedAt,
+		request:   request,
+		body:      placeholder.CompileTemplate(prototype.Response.Body),
+		headers:   compileHeaders(prototype.Response.Headers),
+		contract:  contract,
+	}, nil
+}
+
+// compileHeaders compila los headers de la respuesta como un template; nil si no hay headers
+func compileHeaders(headers map[string]string) *placeholder.Template {
+	if len(headers) == 0 {
+		return nil
+	}
+	input := make(map[string]any, len(headers))
+	for name, value := range headers {
+		input[name] = value
+	}
+	return placeholder.CompileTemplate(input)
+}
+
+// compiledFor devuelve la versión compilada del prototype; si no está en caché o el
+// prototype cambió desde que se compiló (UpdatedAt), se compila de nuevo.
+func (s *PrototypesService) compiledFor(prototype prototypes.PrototypeModel) (*compiledPrototype, error) {
+	if cached, ok := s.compiled.Load(prototype.ID); ok {
+		if c := cached.(*compiledPrototype); c.updatedAt.Equal(prototype.UpdatedAt) {
+			return c, nil
+		}
+	}
+	c, err := s.compile(prototype)
+	if err != nil {
+		return nil, err
+	}
+	s.storeInCache(prototype.ID, c)
+	return c, nil
+}
+
+// storeInCache guarda una versión compilada nueva; Delete y DeleteAll la sacan del caché
+// y SweepCompiled se encarga de los prototypes que caducan por TTL
+func (s *PrototypesService) storeInCache(id string, c *compiledPrototype) {
+	s.compiled.Store(id, c)
+}
+
+// SweepCompiled revisa el caché cada interval, fuera del camino del mock, y saca los
+// prototypes que ya no existen: los que caducan por TTL nunca pasan por Delete.
+func (s *PrototypesService) SweepCompiled(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.evictExpired(ctx)
+			}
+		}
+	}()
+}
+
+func (s *PrototypesService) evictExpired(ctx context.Context) {
+	alive := s.prototypesRepository.FindAll(ctx)
+	if alive.Err != nil {
+		return
+	}
+	ids := make(map[string]bool, len(alive.Data))
+	for _, prototype := range alive.Data {
+		ids[prototype.ID] = true
+	}
+	s.compiled.Range(func(id, _ any) bool {
+		if !ids[id.(string)] {
+			s.compiled.Delete(id)
+		}
+		return true
+	})
+}
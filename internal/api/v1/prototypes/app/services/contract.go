@@ -0,0 +1,114 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"fmt"
+	validator_controller "mocky/internal/context/controllers"
+	"mocky/internal/context/controllers/placeholder"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"strings"
+	"time"
+)
+
+// ContractWarningHeader lleva el resumen de las diferencias entre la respuesta y response.schema
+const ContractWarningHeader = "X-Mocky-Contract-Warning"
+
+// Title del alert de create/update con las diferencias con el contrato
+const ContractAlertTitle = "Response contract"
+
+// códigos de los alerts del service: el controller los distingue por Code, no por el título
+const (
+	ContractAlertCode uint = iota + 1
+)
+
+// máximo de errores que se listan en el header; el log los tiene todos
+const contractWarningMaxErrors = 5
+
+// contractWarning resume los errores del contrato en una línea apta para un header
+func contractWarning(errs []validator_controller.ValidationError) string {
+	items := make([]string, 0, len(errs))
+	for i, err := range errs {
+		if i == contractWarningMaxErrors {
+			items = append(items, fmt.Sprintf("and %d more", len(errs)-i))
+			break
+		}
+		pointer := err.JSONPointer()
+		if pointer == "" {
+			pointer = "/"
+		}
+		items = append(items, fmt.Sprintf("%s %s", pointer, err.Code))
+	}
+	return fmt.Sprintf("response does not match its contract (%d errors): %s", len(errs), strings.Join(items, "; "))
+}
+
+func logContractViolations(cc *customctx.CustomContext, prototype prototypes.PrototypeModel, errs []validator_controller.ValidationError) {
+	entry := logger.FromContext(cc.Context())
+	for _, err := range errs {
+		entry.Warnf("response contract of %q: [%s] %s", prototype.Name, err.Code, err.String())
+	}
+}
+
+// sampleContractViolations renderiza la respuesta con una request de ejemplo (armada a partir
+// de los schemas del request) y la revisa contra el contrato. Si la respuesta no se puede
+// renderizar sin datos reales (p. ej. un dataset vacío) la revisión se omite.
+func (s *PrototypesService) sampleContractViolations(cc *customctx.CustomContext, prototype prototypes.PrototypeModel, compiled *compiledPrototype) []validator_controller.ValidationError {
+	if compiled.contract == nil {
+		return nil
+	}
+
+	request := prototype.Request
+
+	pathParams := map[string]string{}
+	path := request.UrlPath
+	for _, name := range request.PathParamNames() {
+		value := "1"
+		if schema, ok := request.PathParams[name]; ok && schema != "" && !strings.HasPrefix(schema, "^") {
+			value = schema
+		}
+		pathParams[name] = value
+		path = strings.NewReplacer(":"+name, value, "{"+name+"}", value).Replace(path)
+	}
+
+	headers := validator_controller.SampleParams(validator_controller.InHeader, request.HeaderSchema)
+	for name, schema := range request.Headers {
+		if !strings.HasPrefix(schema, "^") {
+			headers[name] = []string{schema}
+		}
+	}
+
+	body, _ := validator_controller.SampleBody(request.BodySchema).(map[string]any)
+	if body == nil {
+		body = map[string]any{}
+	}
+
+	fields := utils.GetFieldsOfLogger(cc.Context())
+
+	sample := placeholder.MockContext{
+		PathParams: pathParams,
+		Query:      validator_controller.SampleParams(validator_controller.InQuery, request.QuerySchema),
+		Headers:    headers,
+		Body:       body,
+		Context:    cc.Context(),
+		Request: placeholder.RequestInfo{
+			Method:         request.Method,
+			URL:            path,
+			Path:           path,
+			ClientIP:       fields.ClientIP,
+			TraceID:        fields.TraceID,
+			PrototypeID:    prototype.ID,
+			PrototypeName:  prototype.Name,
+			PrototypeGroup: prototype.Group,
+			ReceivedAt:     time.Now(),
+		},
+	}
+
+	resolved, err := s.placeholderController.Render(sample, compiled.body)
+	if err != nil {
+		logger.FromContext(cc.Context()).Warnf("response contract not checked with sample data: %s", err.Error())
+		return nil
+	}
+
+	return compiled.contract.Validate(resolved)
+}
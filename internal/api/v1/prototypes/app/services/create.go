@@ -4,9 +4,11 @@ import (
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/utils"
+	"common/utils/cerrs"
 	"mocky/internal/api/v1/prototypes/domain/commands"
 	prototypes "mocky/internal/db/mongo/prototypes"
 	"net/http"
+	"strings"
 )
 
 func (s *PrototypesService) Create(cc *customctx.CustomContext, prototype commands.CreatePrototypeCommand) utils.Response[prototypes.PrototypeModel] {
@@ -21,6 +23,24 @@ func (s *PrototypesService) Create(cc *customctx.CustomContext, prototype comman
 		Request:  prototypeEntity.Request,
 		Response: prototypeEntity.Response,
 		Name:     prototypeEntity.Name,
+		Group:    prototypeEntity.Group,
+
+		Overrides: prototypeEntity.Overrides,
+	}
+
+	compiled, alert, err := s.prepare(cc, prototypeModel, "prototypes.create")
+	if err != nil {
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      err,
+			StatusCode: err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	// mismo method + urlPath: SaveOrUpdate lo reemplaza y en el historial cuenta como update
+	action := prototypes.RevisionCreate
+	if s.exactPrototype(cc, prototypeModel.Request.Method, prototypeModel.Request.UrlPath) != nil {
+		action = prototypes.RevisionUpdate
 	}
 
 	result := s.prototypesRepository.SaveOrUpdate(cc, prototypeModel)
@@ -34,10 +54,62 @@ func (s *PrototypesService) Create(cc *customctx.CustomContext, prototype comman
 	}
 
 	prototypeModel.ID = result.Data
+	prototypeModel = s.storeCompiled(cc, prototypeModel, compiled)
+	s.recordRevision(cc, prototypeModel, action, 0)
 
 	return utils.Response[prototypes.PrototypeModel]{
 		Data:       prototypeModel,
 		StatusCode: http.StatusCreated,
+		Alert:      alert,
 		Success:    true,
 	}
 }
+
+// prepare revisa y compila un prototype antes de guardarlo (create y update): formats
+// desconocidos, regex inválidas y el contrato de la respuesta con datos de ejemplo.
+// En modo warn las diferencias con el contrato se devuelven como alert.
+func (s *PrototypesService) prepare(cc *customctx.CustomContext, prototypeModel prototypes.PrototypeModel, scope string) (*compiledPrototype, *utils.Alert, cerrs.CustomErrorInterface) {
+
+	entry := logger.FromContext(cc.Context())
+
+	if unknown := s.validator.UnknownFormats(prototypeModel.Request, prototypeModel.Response); len(unknown) > 0 {
+		return nil, nil, cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, "unknown format(s) in prototype schemas: "+strings.Join(unknown, ", ")+" (register them in /v1/formats)", scope+".formats"))
+	}
+
+	// Se compila antes de guardar: una regex inválida se rechaza aquí y no al llamar al mock
+	compiled, err := s.compile(prototypeModel)
+	if err != nil {
+		entry.Error(err.Error())
+		return nil, nil, cc.NewError(cerrs.NewCustomError(http.StatusBadRequest, err.Error(), scope+".compile"))
+	}
+
+	// Contrato de la respuesta revisado con una request de ejemplo
+	var alert *utils.Alert
+	if violations := s.sampleContractViolations(cc, prototypeModel, compiled); len(violations) > 0 {
+		logContractViolations(cc, prototypeModel, violations)
+		if prototypeModel.Response.IsStrictContract() {
+			return nil, nil, newContractViolationError(http.StatusUnprocessableEntity, scope+".contract", "", violations)
+		}
+		alert = &utils.Alert{
+			Title:   ContractAlertTitle,
+			Code:    ContractAlertCode,
+			Message: contractWarning(violations),
+			Scope:   scope + ".contract",
+		}
+	}
+
+	return compiled, alert, nil
+}
+
+// storeCompiled guarda en caché la versión compilada del prototype recién guardado; el
+// repositorio asigna las fechas y UpdatedAt identifica la versión compilada.
+func (s *PrototypesService) storeCompiled(cc *customctx.CustomContext, prototypeModel prototypes.PrototypeModel, compiled *compiledPrototype) prototypes.PrototypeModel {
+	saved := s.prototypesRepository.Find(cc.Context(), prototypeModel.ID)
+	if saved.Err != nil {
+		s.compiled.Delete(prototypeModel.ID)
+		return prototypeModel
+	}
+	compiled.updatedAt = saved.Data.UpdatedAt
+	s.storeInCache(saved.Data.ID, compiled)
+	return saved.Data
+}
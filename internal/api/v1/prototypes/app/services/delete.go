@@ -0,0 +1,95 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+)
+
+func (s *PrototypesService) Delete(cc *customctx.CustomContext, id string) utils.Response[string] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Deleting prototype %s", id)
+
+	// la foto del prototype eliminado queda como última revisión, para poder restaurarlo
+	current := s.prototypesRepository.Find(cc.Context(), id)
+
+	if err := s.prototypesRepository.Delete(cc.Context(), id); err != nil {
+		entry.Error(err.Error())
+		code := http.StatusInternalServerError
+		if customErr, ok := err.(cerrs.CustomErrorInterface); ok {
+			code = customErr.GetCode()
+		}
+		return utils.Response[string]{
+			Error:      cerrs.NewCustomError(code, err.Error(), "prototypes.delete"),
+			StatusCode: code,
+			Success:    false,
+		}
+	}
+
+	s.compiled.Delete(id)
+	if current.Err == nil {
+		s.recordRevision(cc, current.Data, prototypes.RevisionDelete, 0)
+	}
+
+	return utils.Response[string]{
+		Data:       id,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// DeleteAll elimina todos los prototypes; Data trae la cantidad eliminada ({"deleted": n})
+func (s *PrototypesService) DeleteAll(cc *customctx.CustomContext) utils.Response[map[string]int64] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Deleting all prototypes")
+
+	deleted := s.snapshots(cc)
+
+	result := s.prototypesRepository.DeleteAll(cc)
+	if result.Err != nil {
+		entry.Error(result.Err.Error())
+		return utils.Response[map[string]int64]{
+			Error:      result.Err,
+			StatusCode: result.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	s.compiled.Range(func(id, _ any) bool {
+		s.compiled.Delete(id)
+		return true
+	})
+
+	for _, snapshot := range deleted {
+		s.recordRevision(cc, snapshot, prototypes.RevisionDelete, 0)
+	}
+
+	return utils.Response[map[string]int64]{
+		Data:       map[string]int64{"deleted": result.Data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// snapshots devuelve la foto completa de todos los prototypes guardados
+func (s *PrototypesService) snapshots(cc *customctx.CustomContext) []prototypes.PrototypeModel {
+	all := s.prototypesRepository.FindAll(cc.Context())
+	if all.Err != nil {
+		return nil
+	}
+
+	snapshots := make([]prototypes.PrototypeModel, 0, len(all.Data))
+	for _, item := range all.Data {
+		if found := s.prototypesRepository.Find(cc.Context(), item.ID); found.Err == nil {
+			snapshots = append(snapshots, found.Data)
+		}
+	}
+	return snapshots
+}
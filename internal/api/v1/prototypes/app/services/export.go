@@ -0,0 +1,44 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+)
+
+// Export devuelve los prototypes completos que cumplen los filtros del listado (sin paginar)
+func (s *PrototypesService) Export(cc *customctx.CustomContext, command commands.ListPrototypesCommand) utils.Response[prototypes.PrototypeModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Exporting prototypes")
+
+	matched := s.prototypesRepository.Matching(command.Criteria, "prototypes", 0, 0)
+	if matched.Err != nil {
+		entry.Error(matched.Err.Error())
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(matched.Err),
+			StatusCode: matched.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	results := make([]prototypes.PrototypeModel, 0, len(matched.Data))
+	for _, item := range matched.Data {
+		prototype := s.prototypesRepository.Find(cc.Context(), item.ID)
+		if prototype.Err != nil {
+			// expiró entre el listado y la lectura
+			continue
+		}
+		results = append(results, prototype.Data)
+	}
+
+	return utils.Response[prototypes.PrototypeModel]{
+		Results:    results,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/domain/saga"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"strings"
+)
+
+// ImportResult resume un import; IDs sigue el orden del bundle
+type ImportResult struct {
+	Imported int      `json:"imported"`
+	Created  int      `json:"created"`
+	Updated  int      `json:"updated"`
+	IDs      []string `json:"ids"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Import guarda todos los prototypes del bundle o ninguno. Primero se revisan y compilan
+// todos (sin escribir nada); después cada uno se guarda como un paso de una saga, y si
+// alguno falla los ya escritos se deshacen: los nuevos se eliminan y los reemplazados
+// (mismo method + urlPath) vuelven a su versión anterior. Si el rollback tampoco puede
+// deshacer alguno, se responde 500 nombrando lo que quedó escrito. Cada prototype
+// guardado deja una revisión "import" en su historial.
+func (s *PrototypesService) Import(cc *customctx.CustomContext, command commands.ImportPrototypesCommand) utils.Response[ImportResult] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Importing %d prototypes", len(command.Prototypes))
+
+	steps := make([]saga.SAGA_Step, 0, len(command.Prototypes))
+	importSteps := make([]*importPrototypeStep, 0, len(command.Prototypes))
+
+	for i, prototype := range command.Prototypes {
+		prototypeEntity := prototype.ToEntity()
+		prototypeModel := prototypes.PrototypeModel{
+			Request:  prototypeEntity.Request,
+			Response: prototypeEntity.Response,
+			Name:     prototypeEntity.Name,
+			Group:    prototypeEntity.Group,
+
+			Overrides: prototypeEntity.Overrides,
+		}
+
+		if command.KeepOverrides {
+			merged, err := s.keepOverrides(cc, prototypeModel)
+			if err != nil {
+				return importFailed(cc, i, prototypeModel.Name, err)
+			}
+			prototypeModel = merged
+		}
+
+		compiled, _, err := s.prepare(cc, prototypeModel, "prototypes.import")
+		if err != nil {
+			return importFailed(cc, i, prototypeModel.Name, err)
+		}
+
+		step := &importPrototypeStep{service: s, index: i, model: prototypeModel, compiled: compiled}
+		steps = append(steps, step)
+		importSteps = append(importSteps, step)
+	}
+
+	controller := &saga.SAGA_Controller{Steps: steps}
+	controller.Payloads = controller.Executed(cc)
+
+	for _, step := range importSteps {
+		if step.err != nil {
+			if controller.RollbackErr != nil {
+				return importRollbackFailed(cc, step, controller.RollbackErr)
+			}
+			return importFailed(cc, step.index, step.model.Name, step.err)
+		}
+	}
+
+	result := ImportResult{Imported: len(importSteps), IDs: make([]string, 0, len(importSteps)), Warnings: command.Warnings}
+	for _, step := range importSteps {
+		// el historial se escribe cuando ya no hay rollback posible
+		s.recordRevision(cc, step.model, prototypes.RevisionImport, 0)
+		result.IDs = append(result.IDs, step.savedID)
+		if step.previous != nil {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+	}
+
+	return utils.Response[ImportResult]{
+		Data:       result,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// keepOverrides copia sobre el prototype importado los overrides del que tiene su mismo method + urlPath
+func (s *PrototypesService) keepOverrides(cc *customctx.CustomContext, prototypeModel prototypes.PrototypeModel) (prototypes.PrototypeModel, cerrs.CustomErrorInterface) {
+	existing := s.exactPrototype(cc, prototypeModel.Request.Method, prototypeModel.Request.UrlPath)
+	if existing == nil {
+		return prototypeModel, nil
+	}
+
+	merged, err := applyOverrides(*existing, prototypeModel)
+	if err != nil {
+		return prototypeModel, cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "prototypes.import.overrides")
+	}
+	return merged, nil
+}
+
+func importFailed(cc *customctx.CustomContext, index int, name string, err cerrs.CustomErrorInterface) utils.Response[ImportResult] {
+	message := fmt.Sprintf("prototypes[%d] (%s): %s; nothing was imported", index, name, err.Error())
+	return utils.Response[ImportResult]{
+		Error:      cc.NewError(cerrs.NewCustomError(err.GetCode(), message, "prototypes.import")),
+		StatusCode: err.GetCode(),
+		Success:    false,
+	}
+}
+
+// importRollbackFailed responde 500 cuando el rollback no pudo deshacer todo: el mensaje
+// nombra los prototypes que quedaron escritos con la versión del bundle
+func importRollbackFailed(cc *customctx.CustomContext, failed *importPrototypeStep, rollbackErr error) utils.Response[ImportResult] {
+	entry := logger.FromContext(cc.Context())
+	entry.Errorf("Import rollback incomplete: %s", rollbackErr.Error())
+
+	message := fmt.Sprintf("prototypes[%d] (%s): %s; rollback incomplete, left in place: %s", failed.index, failed.model.Name, failed.err.Error(), strings.ReplaceAll(rollbackErr.Error(), "\n", "; "))
+	return utils.Response[ImportResult]{
+		Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, message, "prototypes.import.rollback")),
+		StatusCode: http.StatusInternalServerError,
+		Success:    false,
+	}
+}
+
+// importPrototypeStep guarda un prototype del bundle; Rollback lo deshace si llegó a escribirse
+type importPrototypeStep struct {
+	service  *PrototypesService
+	index    int
+	model    prototypes.PrototypeModel
+	compiled *compiledPrototype
+
+	previous *prototypes.PrototypeModel // versión reemplazada (mismo method + urlPath)
+	savedID  string
+	err      cerrs.CustomErrorInterface
+}
+
+func (st *importPrototypeStep) Call(cc *customctx.CustomContext, _ utils.Result[saga.Payload], _ map[string]utils.Result[saga.Payload]) utils.Result[saga.Payload] {
+	s := st.service
+
+	st.previous = s.exactPrototype(cc, st.model.Request.Method, st.model.Request.UrlPath)
+
+	result := s.prototypesRepository.SaveOrUpdate(cc, st.model)
+	if result.Err != nil {
+		st.err = result.Err
+		return utils.Result[saga.Payload]{Err: result.Err}
+	}
+
+	st.savedID = result.Data
+	st.model.ID = result.Data
+	st.model = s.storeCompiled(cc, st.model, st.compiled)
+
+	return utils.Result[saga.Payload]{Data: saga.Payload{"id": result.Data}}
+}
+
+func (st *importPrototypeStep) Rollback(cc *customctx.CustomContext) error {
+	if st.savedID == "" {
+		return nil
+	}
+	s := st.service
+
+	s.compiled.Delete(st.savedID)
+	if st.previous != nil {
+		if err := s.prototypesRepository.Update(cc.Context(), *st.previous); err != nil {
+			return fmt.Errorf("%s (%s %s, id %s) was not restored to its previous version: %w", st.Produce(), st.model.Request.Method, st.model.Request.UrlPath, st.savedID, err)
+		}
+		return nil
+	}
+	if err := s.prototypesRepository.Delete(cc.Context(), st.savedID); err != nil {
+		return fmt.Errorf("%s (%s %s, id %s) was not deleted: %w", st.Produce(), st.model.Request.Method, st.model.Request.UrlPath, st.savedID, err)
+	}
+	return nil
+}
+
+func (st *importPrototypeStep) Produce() string {
+	return fmt.Sprintf("prototypes[%d]", st.index)
+}
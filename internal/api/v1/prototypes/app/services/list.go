@@ -4,21 +4,33 @@ import (
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/utils"
+	"mocky/internal/api/v1/prototypes/domain/commands"
 	"mocky/internal/db/mongo/prototypes"
 	"net/http"
 )
 
-func (s *PrototypesService) List(cc *customctx.CustomContext) utils.Response[prototypes.PrototypeListModel] {
+func (s *PrototypesService) List(cc *customctx.CustomContext, command commands.ListPrototypesCommand) utils.Response[prototypes.PrototypeListModel] {
 
 	entry := logger.FromContext(cc.Context())
 
 	entry.Info("Listing prototypes")
 
-	prototypesList := s.prototypesRepository.FindAll(cc.Context())
+	total := s.prototypesRepository.CountMatching(command.Criteria, "prototypes")
+	if total.Err != nil {
+		entry.Error(total.Err.Error())
+		return utils.Response[prototypes.PrototypeListModel]{
+			StatusCode: total.Err.GetCode(),
+			Error:      cc.NewError(total.Err),
+			Success:    false,
+		}
+	}
+
+	prototypesList := s.prototypesRepository.Matching(command.Criteria, "prototypes", command.Offset, command.Limit)
 	if prototypesList.Err != nil {
+		entry.Error(prototypesList.Err.Error())
 		return utils.Response[prototypes.PrototypeListModel]{
-			StatusCode: http.StatusInternalServerError,
-			Error:      prototypesList.Err,
+			StatusCode: prototypesList.Err.GetCode(),
+			Error:      cc.NewError(prototypesList.Err),
 			Success:    false,
 		}
 	}
@@ -26,6 +38,11 @@ func (s *PrototypesService) List(cc *customctx.CustomContext) utils.Response[pro
 	return utils.Response[prototypes.PrototypeListModel]{
 		StatusCode: http.StatusOK,
 		Results:    prototypesList.Data,
-		Success:    true,
+		Pagination: &utils.Pagination{
+			Total:  total.Data,
+			Offset: command.Offset,
+			Limit:  command.Limit,
+		},
+		Success: true,
 	}
 }
@@ -5,18 +5,29 @@ import (
 	"common/domain/logger"
 	"common/utils"
 	"common/utils/cerrs"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	validator_controller "mocky/internal/context/controllers"
 	"mocky/internal/context/controllers/placeholder"
 	"mocky/internal/core/settings"
 	"net/http"
-	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
-func (s *PrototypesService) Mock(cc *customctx.CustomContext, request *http.Request, pathParams map[string]string, headers map[string]string, query map[string]string) utils.Response[map[string]any] {
+// MockResponse es la respuesta resuelta del mock. Rendered trae el body ya serializado
+// cuando el template del prototype es estático (sin placeholders); ContractWarning resume
+// las diferencias con response.schema (modo warn).
+type MockResponse struct {
+	Headers         map[string]string
+	Body            any
+	Rendered        []byte
+	ContractWarning string
+}
+
+func (s *PrototypesService) Mock(cc *customctx.CustomContext, request *http.Request, pathParams map[string]string, headers map[string][]string, query map[string][]string, receivedAt time.Time) utils.Response[*MockResponse] {
 
 	entry := logger.FromContext(cc.Context())
 
@@ -33,209 +44,343 @@ func (s *PrototypesService) Mock(cc *customctx.CustomContext, request *http.Requ
 
 	if prototypeModel.Err != nil {
 		entry.Error(prototypeModel.Err.Error())
-		return utils.Response[map[string]any]{
+		return utils.Response[*MockResponse]{
 			Error:      prototypeModel.Err,
 			StatusCode: http.StatusNotFound,
 			Success:    false,
 		}
 	}
 
-	// Verificar los Headers
-	headersResult := s.verifyHeaders(cc, prototypeModel.Data.ID, request, prototypeModel.Data.Request.Headers)
-	if headersResult.Err != nil {
-		entry.Error(headersResult.Err.Error())
-		return utils.Response[map[string]any]{
-			Error:      headersResult.Err,
-			StatusCode: http.StatusBadRequest,
+	compiled, err := s.compiledFor(prototypeModel.Data)
+	if err != nil {
+		entry.Error(err.Error())
+		return utils.Response[*MockResponse]{
+			Error:      cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "compile_prototype"),
+			StatusCode: http.StatusInternalServerError,
 			Success:    false,
 		}
 	}
 
-	// verificar los path params
-	pathParamsResult := s.verifyPathParams(cc, prototypeModel.Data.ID, request, prototypeModel.Data.Request.PathParams)
-	if pathParamsResult.Err != nil {
-		entry.Error(pathParamsResult.Err.Error())
-		return utils.Response[map[string]any]{
-			Error:      pathParamsResult.Err,
-			StatusCode: http.StatusBadRequest,
-			Success:    false,
+	// Segmentos dinámicos del urlPath (/v1/users/:user_id)
+	if templateParams, _, ok := prototypeModel.Data.Request.MatchPath(realPath); ok {
+		for name, value := range templateParams {
+			pathParams[name] = value
 		}
 	}
 
+	// Headers, matchers y path params se validan junto con query params y body: una sola
+	// respuesta lista todos los problemas
+	var validationErrors []validator_controller.ValidationError
+
+	validationErrors = append(validationErrors, s.verifyHeaders(cc, compiled.request, request, prototypeModel.Data.Request.Headers)...)
+	validationErrors = append(validationErrors, s.verifyValuesMatchers(cc, compiled.request, validator_controller.InHeader, http.Header(headers).Values, prototypeModel.Data.Request.HeaderMatchers)...)
+	validationErrors = append(validationErrors, s.verifyValuesMatchers(cc, compiled.request, validator_controller.InQuery, func(key string) []string { return query[key] }, prototypeModel.Data.Request.QueryMatchers)...)
+	validationErrors = append(validationErrors, s.verifyPathParams(cc, compiled.request, request, pathParams, prototypeModel.Data.Request.PathParams)...)
+
 	// Verificar las Properties de la request
 
-	bodyMap, err := _convertBodyToMap(request)
+	bodyMap, rawBody, err := _convertBodyToMap(request)
 	if err != nil {
 		entry.Error(err.Error())
-		return utils.Response[map[string]any]{
+		return utils.Response[*MockResponse]{
 			Error:      cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "convert_body_to_map"),
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
 		}
 	}
 
-	if prototypeModel.Data.Request.BodySchema != nil && prototypeModel.Data.Request.BodySchema.TypeSchema != "" {
+	validationErrors = append(validationErrors, compiled.request.ValidateParams(validator_controller.InQuery, prototypeModel.Data.Request.QuerySchema, query)...)
+	validationErrors = append(validationErrors, compiled.request.ValidateParams(validator_controller.InHeader, prototypeModel.Data.Request.HeaderSchema, headers)...)
 
-		propertiesResult := s.validator.Validate(*prototypeModel.Data.Request.BodySchema, bodyMap)
-		if len(propertiesResult) > 0 {
+	if prototypeModel.Data.Request.BodySchema != nil && prototypeModel.Data.Request.BodySchema.TypeSchema != "" {
 
-			for _, err := range propertiesResult {
-				entry.Error(err.String())
-				cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.String(), "validate_body"))
-			}
+		// forms y XML llegan como texto: se convierten a los tipos del schema (también para los templates)
+		if _isTextOnlyBody(request) {
+			s.validator.CoerceTextValues(*prototypeModel.Data.Request.BodySchema, bodyMap)
+		}
 
-			return utils.Response[map[string]any]{
-				Error:      cerrs.NewCustomError(http.StatusUnprocessableEntity, propertiesResult[len(propertiesResult)-1].String(), "validate_body"),
-				StatusCode: http.StatusUnprocessableEntity,
-				Success:    false,
-			}
+		// un body vacío se valida como objeto vacío; los defaults se escriben sobre bodyMap
+		payload := _bodyInstance(request, bodyMap, rawBody)
+		if payload == nil {
+			payload = bodyMap
 		}
 
+		validationErrors = append(validationErrors, compiled.request.Validate(*prototypeModel.Data.Request.BodySchema, payload)...)
 	}
-	// Contruir la respuesta
 
-	pathParamsMap := request.URL.Query()
-	headersMap := request.Header
+	if prototypeModel.Data.Request.JSONSchema != nil {
+		validationErrors = append(validationErrors, compiled.request.ValidateJSONSchema(_bodyInstance(request, bodyMap, rawBody))...)
+	}
+
+	if len(validationErrors) > 0 {
+
+		for _, err := range validationErrors {
+			entry.Errorf("%s %s", err.Location(), err.String())
+		}
+
+		return utils.Response[*MockResponse]{
+			Error:      newValidationFailedError(prototypeModel.Data.Request.ErrorFormat, validationErrors),
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+		}
+	}
 
-	fmt.Println(pathParamsMap)
-	fmt.Println(headersMap)
-	fmt.Println(bodyMap)
+	// Contruir la respuesta
 
 	mockContext := placeholder.MockContext{
 		PathParams: pathParams,
 		Query:      query,
 		Headers:    headers,
 		Body:       bodyMap,
+		Context:    cc.Context(),
+		Request: placeholder.RequestInfo{
+			Method:         request.Method,
+			URL:            _fullURL(request),
+			Path:           realPath,
+			Host:           request.Host,
+			ClientIP:       utils.GetFieldsOfLogger(cc.Context()).ClientIP,
+			TraceID:        utils.GetFieldsOfLogger(cc.Context()).TraceID,
+			PrototypeID:    prototypeModel.Data.ID,
+			PrototypeName:  prototypeModel.Data.Name,
+			PrototypeGroup: prototypeModel.Data.Group,
+			ReceivedAt:     receivedAt,
+			RawBody:        string(rawBody),
+		},
 	}
 
-	resolved, err := s.placeholderController.Resolve(mockContext, prototypeModel.Data.Response.Body)
+	resolved, err := s.placeholderController.Render(mockContext, compiled.body)
+	var responseHeaders map[string]string
+	if err == nil {
+		responseHeaders, err = s.renderHeaders(mockContext, compiled.headers, prototypeModel.Data.Response.Headers)
+	}
+	var notFound *placeholder.RecordNotFoundError
+	if errors.As(err, &notFound) {
+		entry.Error(err.Error())
+		return utils.Response[*MockResponse]{
+			Error:      cerrs.NewCustomError(http.StatusNotFound, err.Error(), "placeholder_controller.dataset_lookup"),
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+		}
+	}
 	if err != nil {
 		entry.Error(err.Error())
-		return utils.Response[map[string]any]{
+		return utils.Response[*MockResponse]{
 			Error:      cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "placeholder_controller"),
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
 		}
 	}
 
-	pretty, _ := json.MarshalIndent(resolved, "", "  ")
-	fmt.Println("=== Response con valores (faker + args opcionales) ===")
-	fmt.Println(string(pretty))
+	// Contrato de la respuesta: en modo strict una respuesta fuera de contrato es un 500
+	warning := ""
+	if violations := compiled.contract.Validate(resolved); len(violations) > 0 {
+		logContractViolations(cc, prototypeModel.Data, violations)
+		if prototypeModel.Data.Response.IsStrictContract() {
+			return utils.Response[*MockResponse]{
+				Error:      newContractViolationError(http.StatusInternalServerError, "response_contract", prototypeModel.Data.Request.ErrorFormat, violations),
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+			}
+		}
+		warning = contractWarning(violations)
+	}
 
 	if prototypeModel.Data.Request.Delay > 0 {
 		time.Sleep(time.Duration(prototypeModel.Data.Request.Delay) * time.Millisecond)
 	}
 
-	entry.Infof("PrototypeModel: %v", prototypeModel.Data)
+	entry.Infof("Mocked prototype %s (%s)", prototypeModel.Data.ID, prototypeModel.Data.Name)
+
+	rendered, _ := compiled.body.Static()
+
+	statusCode := http.StatusOK
+	if prototypeModel.Data.Response.StatusCode != 0 {
+		statusCode = prototypeModel.Data.Response.StatusCode
+	}
+
+	return utils.Response[*MockResponse]{
+		Data:       &MockResponse{Headers: responseHeaders, Body: resolved, Rendered: rendered, ContractWarning: warning},
+		StatusCode: statusCode,
+	}
+}
+
+// renderHeaders resuelve los placeholders de los headers de la respuesta con su template compilado
+func (s *PrototypesService) renderHeaders(ctx placeholder.MockContext, tpl *placeholder.Template, headers map[string]string) (map[string]string, error) {
+	if tpl == nil {
+		return headers, nil
+	}
+	if _, static := tpl.Static(); static {
+		return headers, nil
+	}
 
-	return utils.Response[map[string]any]{
-		Data:       resolved.(map[string]any),
-		StatusCode: http.StatusOK,
+	resolved, err := s.placeholderController.Render(ctx, tpl)
+	if err != nil {
+		return nil, err
 	}
+	values, _ := resolved.(map[string]any)
+	rendered := make(map[string]string, len(values))
+	for name, value := range values {
+		if value != nil {
+			rendered[name] = fmt.Sprint(value)
+		}
+	}
+	return rendered, nil
 }
 
 func (s *PrototypesService) verifyPathParams(
 	cc *customctx.CustomContext,
-	prototypeID string,
+	compiled *validator_controller.CompiledRequest,
 	request *http.Request,
+	pathParams map[string]string,
 	pathParamsSchemas map[string]string,
-) utils.Result[map[string]interface{}] {
+) []validator_controller.ValidationError {
 	entry := logger.FromContext(cc.Context())
 
-	entry.Info("Verifying headers")
+	entry.Info("Verifying path params")
 
-	for pathParam, schema := range pathParamsSchemas {
-		fmt.Println(pathParam, schema)
+	var errs []validator_controller.ValidationError
+	for _, pathParam := range sortedKeys(pathParamsSchemas) {
+		schema := pathParamsSchemas[pathParam]
 
-		pathParamReceived := request.URL.Query().Get(pathParam)
+		// Segmento dinámico del urlPath; si no existe, se busca en el query string
+		pathParamReceived, ok := pathParams[pathParam]
+		if !ok {
+			pathParamReceived = request.URL.Query().Get(pathParam)
+		}
 		if pathParamReceived == "" {
-			return utils.Result[map[string]interface{}]{Err: cerrs.NewCustomError(http.StatusBadRequest, "Path param "+pathParam+" is required", "verify_path_params")}
+			errs = append(errs, validator_controller.ParamError(validator_controller.InPath, pathParam, validator_controller.CodeRequired, "missing required path param"))
+			continue
 		}
 
-		if strings.HasPrefix(schema, "^") {
-			regex := schema[1:]
-			match, _ := regexp.MatchString(regex, pathParamReceived)
-			if !match {
-				return utils.Result[map[string]interface{}]{Err: cerrs.NewCustomError(http.StatusBadRequest, "Path param "+pathParam+" does not match the schema", "verify_path_params")}
-			}
-		} else {
-			if pathParamReceived != schema {
-				return utils.Result[map[string]interface{}]{
-					Err: cerrs.NewCustomError(
-						http.StatusBadRequest,
-						"Path param "+pathParam+" does not match the schema, check the prototype with ID: "+prototypeID,
-						"verify_path_params",
-					),
-				}
-			}
+		if !compiled.Matches(schema, pathParamReceived) {
+			errs = append(errs, mismatchError(validator_controller.InPath, pathParam, schema))
 		}
 	}
 
-	return utils.Result[map[string]interface{}]{Data: map[string]interface{}{}}
+	return errs
 }
 
 func (s *PrototypesService) verifyHeaders(
 	cc *customctx.CustomContext,
-	prototypeID string,
+	compiled *validator_controller.CompiledRequest,
 	request *http.Request,
 	headersSchemas map[string]string,
-) utils.Result[map[string]interface{}] {
+) []validator_controller.ValidationError {
 
 	entry := logger.FromContext(cc.Context())
 
 	entry.Info("Verifying headers")
 
-	for header, schema := range headersSchemas {
-		fmt.Println(header, schema)
+	var errs []validator_controller.ValidationError
+	for _, header := range sortedKeys(headersSchemas) {
+		schema := headersSchemas[header]
 
-		headerReceived := request.Header.Get(header)
-		if headerReceived == "" {
-			return utils.Result[map[string]interface{}]{Err: cerrs.NewCustomError(http.StatusBadRequest, "Header "+header+" is required", "verify_headers")}
+		headersReceived := request.Header.Values(header)
+		if len(headersReceived) == 0 {
+			errs = append(errs, validator_controller.ParamError(validator_controller.InHeader, header, validator_controller.CodeRequired, "missing required header"))
+			continue
 		}
 
-		if strings.HasPrefix(schema, "^") {
-			regex := schema[1:]
-			match, _ := regexp.MatchString(regex, headerReceived)
-			if !match {
-				return utils.Result[map[string]interface{}]{Err: cerrs.NewCustomError(http.StatusBadRequest, "Header "+header+" does not match the schema", "verify_headers")}
-			}
-		} else {
-			if headerReceived != schema {
-				return utils.Result[map[string]interface{}]{
-					Err: cerrs.NewCustomError(
-						http.StatusBadRequest,
-						"Header "+header+" does not match the schema, check the prototype with ID: "+prototypeID,
-						"verify_headers",
-					),
-				}
+		// Basta con que uno de los valores repetidos cumpla
+		matched := false
+		for _, headerReceived := range headersReceived {
+			if compiled.Matches(schema, headerReceived) {
+				matched = true
+				break
 			}
 		}
+
+		if !matched {
+			errs = append(errs, mismatchError(validator_controller.InHeader, header, schema))
+		}
 	}
 
-	return utils.Result[map[string]interface{}]{Data: map[string]interface{}{}}
+	return errs
 }
 
-func _convertBodyToMap(r *http.Request) (map[string]any, error) {
-	if r.Body == nil {
-		return map[string]any{}, nil
+// mismatchError: el valor no cumple la regex ("^...") o no es el valor exacto del prototype
+func mismatchError(in, name, schema string) validator_controller.ValidationError {
+	if strings.HasPrefix(schema, "^") {
+		return validator_controller.ParamError(in, name, validator_controller.CodePattern, "does not match the pattern")
 	}
+	return validator_controller.ParamError(in, name, validator_controller.CodeConst, "does not match the expected value")
+}
 
-	bodyBytes, err := io.ReadAll(r.Body)
-	if err != nil {
-		return nil, err
-	}
-	defer r.Body.Close()
+// verifyValuesMatchers valida parámetros multi-valor (in = header o query): patrón sobre
+// cualquiera/todos los valores y cantidad
+func (s *PrototypesService) verifyValuesMatchers(
+	cc *customctx.CustomContext,
+	compiled *validator_controller.CompiledRequest,
+	in string,
+	valuesOf func(key string) []string,
+	matchers map[string]entities.ValuesMatcherEntity,
+) []validator_controller.ValidationError {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Verifying %s matchers", in)
+
+	var errs []validator_controller.ValidationError
+	for _, name := range sortedKeys(matchers) {
+		matcher := matchers[name]
+		values := valuesOf(name)
+
+		switch {
+		case matcher.Count != nil && len(values) != *matcher.Count:
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodeCount, fmt.Sprintf("must have exactly %d values, got %d", *matcher.Count, len(values))))
+			continue
+		case matcher.MinCount != nil && len(values) < *matcher.MinCount:
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodeMinItems, fmt.Sprintf("must have at least %d values, got %d", *matcher.MinCount, len(values))))
+			continue
+		case matcher.MaxCount != nil && len(values) > *matcher.MaxCount:
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodeMaxItems, fmt.Sprintf("must have at most %d values, got %d", *matcher.MaxCount, len(values))))
+			continue
+		}
+
+		if matcher.Pattern == "" {
+			continue
+		}
+
+		if len(values) == 0 {
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodeRequired, "missing required "+in))
+			continue
+		}
 
-	if len(bodyBytes) == 0 {
-		return map[string]any{}, nil
+		matches := 0
+		for _, value := range values {
+			if compiled.Matches(matcher.Pattern, value) {
+				matches++
+			}
+		}
+
+		if matcher.Match == entities.MatchAllValues && matches != len(values) {
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodePattern, fmt.Sprintf("all values must match the pattern (%d of %d do not)", len(values)-matches, len(values))))
+		} else if matches == 0 {
+			errs = append(errs, validator_controller.ParamError(in, name, validator_controller.CodePattern, "no value matches the pattern"))
+		}
 	}
 
-	var bodyMap map[string]any
-	if err := json.Unmarshal(bodyBytes, &bodyMap); err == nil {
-		// retornamos directamente el JSON como map[string]any
-		return bodyMap, nil
+	return errs
+}
+
+// sortedKeys ordena los nombres para que los errores salgan siempre en el mismo orden
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	// si no es JSON válido, lo devolvemos como {"raw": "..."}
-	return map[string]any{"raw": string(bodyBytes)}, nil
+// _fullURL reconstruye la URL completa tal como la envió el cliente
+func _fullURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
 }
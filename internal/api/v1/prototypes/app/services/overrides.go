@@ -0,0 +1,126 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"strconv"
+	"strings"
+)
+
+// applyOverrides copia sobre el prototype generado (p. ej. desde un OpenAPI) los valores
+// que el prototype guardado marcó como overrides. Un override que ya no existe en el
+// guardado (se borró a mano) también se borra del generado.
+func applyOverrides(existing, generated prototypes.PrototypeModel) (prototypes.PrototypeModel, error) {
+	if len(existing.Overrides) == 0 {
+		return generated, nil
+	}
+
+	existingDoc, err := toDocument(existing)
+	if err != nil {
+		return generated, err
+	}
+	generatedDoc, err := toDocument(generated)
+	if err != nil {
+		return generated, err
+	}
+
+	for _, path := range existing.Overrides {
+		segments := strings.Split(path, ".")
+		value, found := lookupPath(existingDoc, segments)
+		if !found {
+			deletePath(generatedDoc, segments)
+			continue
+		}
+		if err := setOverride(generatedDoc, segments, value); err != nil {
+			return generated, fmt.Errorf("override %q: %w", path, err)
+		}
+	}
+
+	raw, err := json.Marshal(generatedDoc)
+	if err != nil {
+		return generated, err
+	}
+	var merged prototypes.PrototypeModel
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return generated, fmt.Errorf("overrides: %w", err)
+	}
+	merged.Overrides = existing.Overrides
+	return merged, nil
+}
+
+func toDocument(model prototypes.PrototypeModel) (map[string]any, error) {
+	raw, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	return doc, json.Unmarshal(raw, &doc)
+}
+
+func lookupPath(node any, segments []string) (any, bool) {
+	for _, segment := range segments {
+		switch typed := node.(type) {
+		case map[string]any:
+			next, ok := typed[segment]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			node = typed[index]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// setOverride asigna value en la ruta; crea los objetos intermedios que falten
+func setOverride(node any, segments []string, value any) error {
+	last := len(segments) == 1
+
+	switch typed := node.(type) {
+	case map[string]any:
+		if last {
+			typed[segments[0]] = value
+			return nil
+		}
+		next, ok := typed[segments[0]]
+		if !ok || next == nil {
+			next = map[string]any{}
+			typed[segments[0]] = next
+		}
+		return setOverride(next, segments[1:], value)
+
+	case []any:
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 {
+			return fmt.Errorf("%q is not an array index", segments[0])
+		}
+		if index >= len(typed) {
+			return fmt.Errorf("index %d out of range (array of %d)", index, len(typed))
+		}
+		if last {
+			typed[index] = value
+			return nil
+		}
+		return setOverride(typed[index], segments[1:], value)
+	}
+
+	return fmt.Errorf("cannot set %q: parent value is %T, not an object or array", segments[0], node)
+}
+
+func deletePath(node any, segments []string) {
+	parent, found := lookupPath(node, segments[:len(segments)-1])
+	if !found {
+		return
+	}
+	if object, ok := parent.(map[string]any); ok {
+		delete(object, segments[len(segments)-1])
+	}
+}
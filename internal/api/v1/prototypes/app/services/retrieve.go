@@ -17,8 +17,8 @@ func (s *PrototypesService) Retrieve(cc *customctx.CustomContext, id string) uti
 	prototype := s.prototypesRepository.Find(cc.Context(), id)
 	if prototype.Err != nil {
 		return utils.Response[prototypes.PrototypeModel]{
-			Error:      prototype.Err,
-			StatusCode: http.StatusInternalServerError,
+			Error:      cc.NewError(prototype.Err),
+			StatusCode: prototype.Err.GetCode(),
 			Success:    false,
 		}
 	}
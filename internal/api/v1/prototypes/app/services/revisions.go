@@ -0,0 +1,366 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"encoding/json"
+	"fmt"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AuthorHeader identifica quién hace un cambio; sin el header queda la IP del cliente
+const AuthorHeader = "X-Mocky-Author"
+
+type authorKey struct{}
+
+// WithAuthor guarda en el contexto el autor que se anota en las revisiones
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+func authorFrom(cc *customctx.CustomContext) string {
+	if author, ok := cc.Context().Value(authorKey{}).(string); ok && author != "" {
+		return author
+	}
+	return "anonymous"
+}
+
+// campos de la foto que cambian en cada escritura y no cuentan como diferencia
+var revisionBookkeepingFields = map[string]bool{"id": true, "createdAt": true, "updatedAt": true}
+
+// RevisionChange es una diferencia entre dos revisiones; Path usa la misma sintaxis que PATCH
+type RevisionChange struct {
+	Path string `json:"path"`
+	Op   string `json:"op"` // added, removed o changed
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+}
+
+type RevisionDiff struct {
+	PrototypeID string           `json:"prototypeId"`
+	From        int              `json:"from"`
+	To          int              `json:"to"`
+	Changes     []RevisionChange `json:"changes"`
+}
+
+// recordRevision agrega la foto del prototype recién escrito a su historial. El cambio ya
+// está guardado, así que si el historial falla solo se registra en el log.
+func (s *PrototypesService) recordRevision(cc *customctx.CustomContext, snapshot prototypes.PrototypeModel, action string, restoredFrom int) {
+	result := s.revisionsRepository.Append(cc.Context(), prototypes.PrototypeRevisionModel{
+		PrototypeID:  snapshot.ID,
+		Action:       action,
+		Author:       authorFrom(cc),
+		RestoredFrom: restoredFrom,
+		Snapshot:     copySnapshot(snapshot),
+	})
+	if result.Err != nil {
+		logger.FromContext(cc.Context()).Errorf("Revision %s of prototype %s was not recorded: %s", action, snapshot.ID, result.Err.Error())
+	}
+}
+
+// copySnapshot copia el prototype completo (body, schemas y overrides son mapas compartidos),
+// para que una revisión no cambie cuando se edita o se restaura el prototype
+func copySnapshot(snapshot prototypes.PrototypeModel) prototypes.PrototypeModel {
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return snapshot
+	}
+	var copied prototypes.PrototypeModel
+	if err := json.Unmarshal(raw, &copied); err != nil {
+		return snapshot
+	}
+	return copied
+}
+
+// Revisions lista el historial de un prototype, de la más antigua a la más nueva. Un
+// prototype eliminado conserva su historial.
+func (s *PrototypesService) Revisions(cc *customctx.CustomContext, id string) utils.Response[prototypes.PrototypeRevisionListModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Listing revisions of prototype %s", id)
+
+	revisions := s.revisionsRepository.List(cc.Context(), id)
+	if revisions.Err != nil {
+		entry.Error(revisions.Err.Error())
+		return utils.Response[prototypes.PrototypeRevisionListModel]{
+			Error:      cc.NewError(revisions.Err),
+			StatusCode: revisions.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	if len(revisions.Data) == 0 {
+		if current := s.prototypesRepository.Find(cc.Context(), id); current.Err != nil {
+			return utils.Response[prototypes.PrototypeRevisionListModel]{
+				Error:      cc.NewError(current.Err),
+				StatusCode: current.Err.GetCode(),
+				Success:    false,
+			}
+		}
+	}
+
+	return utils.Response[prototypes.PrototypeRevisionListModel]{
+		StatusCode: http.StatusOK,
+		Results:    revisions.Data,
+		Success:    true,
+	}
+}
+
+// Revision devuelve una revisión con la foto completa del prototype
+func (s *PrototypesService) Revision(cc *customctx.CustomContext, id string, revision int) utils.Response[prototypes.PrototypeRevisionModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Retrieving revision %d of prototype %s", revision, id)
+
+	found := s.revisionsRepository.FindRevision(cc.Context(), id, revision)
+	if found.Err != nil {
+		return utils.Response[prototypes.PrototypeRevisionModel]{
+			Error:      cc.NewError(found.Err),
+			StatusCode: found.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	return utils.Response[prototypes.PrototypeRevisionModel]{
+		StatusCode: http.StatusOK,
+		Data:       found.Data,
+		Success:    true,
+	}
+}
+
+// DiffRevisions compara campo a campo las fotos de dos revisiones. Con to=0 se usa la
+// última revisión y con from=0 la anterior a to.
+func (s *PrototypesService) DiffRevisions(cc *customctx.CustomContext, id string, from int, to int) utils.Response[RevisionDiff] {
+
+	entry := logger.FromContext(cc.Context())
+
+	if to == 0 {
+		revisions := s.revisionsRepository.List(cc.Context(), id)
+		if revisions.Err != nil {
+			entry.Error(revisions.Err.Error())
+			return utils.Response[RevisionDiff]{
+				Error:      cc.NewError(revisions.Err),
+				StatusCode: revisions.Err.GetCode(),
+				Success:    false,
+			}
+		}
+		if count := len(revisions.Data); count > 0 {
+			to = revisions.Data[count-1].Revision
+		}
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	entry.Infof("Diffing revisions %d and %d of prototype %s", from, to, id)
+
+	older := s.revisionsRepository.FindRevision(cc.Context(), id, from)
+	if older.Err != nil {
+		return utils.Response[RevisionDiff]{
+			Error:      cc.NewError(older.Err),
+			StatusCode: older.Err.GetCode(),
+			Success:    false,
+		}
+	}
+	newer := s.revisionsRepository.FindRevision(cc.Context(), id, to)
+	if newer.Err != nil {
+		return utils.Response[RevisionDiff]{
+			Error:      cc.NewError(newer.Err),
+			StatusCode: newer.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	changes, err := diffSnapshots(older.Data.Snapshot, newer.Data.Snapshot)
+	if err != nil {
+		entry.Error(err.Error())
+		return utils.Response[RevisionDiff]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "prototypes.revisions.diff")),
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[RevisionDiff]{
+		StatusCode: http.StatusOK,
+		Data:       RevisionDiff{PrototypeID: id, From: from, To: to, Changes: changes},
+		Success:    true,
+	}
+}
+
+// RestoreRevision vuelve el prototype a la foto de una revisión, con las mismas revisiones
+// que un PUT, y lo anota como una revisión nueva. Si el prototype se eliminó, se vuelve a
+// crear con su mismo ID.
+func (s *PrototypesService) RestoreRevision(cc *customctx.CustomContext, id string, revision int) utils.Response[prototypes.PrototypeModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Restoring revision %d of prototype %s", revision, id)
+
+	found := s.revisionsRepository.FindRevision(cc.Context(), id, revision)
+	if found.Err != nil {
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(found.Err),
+			StatusCode: found.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	snapshot := copySnapshot(found.Data.Snapshot)
+	prototypeModel := prototypes.PrototypeModel{
+		ID:        id,
+		CreatedAt: snapshot.CreatedAt,
+		Request:   snapshot.Request,
+		Response:  snapshot.Response,
+		Name:      snapshot.Name,
+		Group:     snapshot.Group,
+		Overrides: snapshot.Overrides,
+	}
+
+	// el prototype se revisa de nuevo: desde la revisión pudo cambiar, p. ej., un format registrado
+	compiled, alert, err := s.prepare(cc, prototypeModel, "prototypes.restore")
+	if err != nil {
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      err,
+			StatusCode: err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	if prototypeModel.Request.BodySchema != nil && prototypeModel.Request.BodySchema.TypeSchema == "" {
+		prototypeModel.Request.BodySchema = nil
+	}
+
+	if err := s.writeRestored(cc, prototypeModel); err != nil {
+		entry.Error(err.Error())
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(err),
+			StatusCode: err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	prototypeModel = s.storeCompiled(cc, prototypeModel, compiled)
+	s.recordRevision(cc, prototypeModel, prototypes.RevisionRestore, revision)
+
+	return utils.Response[prototypes.PrototypeModel]{
+		Data:       prototypeModel,
+		StatusCode: http.StatusOK,
+		Alert:      alert,
+		Success:    true,
+	}
+}
+
+// writeRestored reemplaza el prototype vigente o, si ya no existe, lo crea con su ID
+// siempre que otro prototype no haya tomado su method + urlPath
+func (s *PrototypesService) writeRestored(cc *customctx.CustomContext, prototypeModel prototypes.PrototypeModel) cerrs.CustomErrorInterface {
+	current := s.prototypesRepository.Find(cc.Context(), prototypeModel.ID)
+	if current.Err == nil {
+		prototypeModel.CreatedAt = current.Data.CreatedAt
+		if err := s.prototypesRepository.Update(cc.Context(), prototypeModel); err != nil {
+			if customErr, ok := err.(cerrs.CustomErrorInterface); ok {
+				return customErr
+			}
+			return cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "prototypes.restore")
+		}
+		return nil
+	}
+	if current.Err.GetCode() != http.StatusNotFound {
+		return current.Err
+	}
+
+	if other := s.exactPrototype(cc, prototypeModel.Request.Method, prototypeModel.Request.UrlPath); other != nil {
+		return cerrs.NewCustomError(http.StatusConflict, "prototype "+other.ID+" now serves "+prototypeModel.Request.Method+" "+prototypeModel.Request.UrlPath, "prototypes.restore")
+	}
+	return s.prototypesRepository.SaveWithID(cc.Context(), prototypeModel.ID, prototypeModel).Err
+}
+
+// exactPrototype devuelve el prototype guardado con exactamente ese method + urlPath (no
+// uno con path dinámico que lo cubra), o nil
+func (s *PrototypesService) exactPrototype(cc *customctx.CustomContext, method string, urlPath string) *prototypes.PrototypeModel {
+	existing := s.prototypesRepository.GetByPath(cc, urlPath, method)
+	if existing.Err != nil || existing.Data.Request.UrlPath != urlPath || !strings.EqualFold(existing.Data.Request.Method, method) {
+		return nil
+	}
+	return &existing.Data
+}
+
+// diffSnapshots compara dos fotos como documentos JSON; los cambios salen ordenados por ruta
+func diffSnapshots(older, newer prototypes.PrototypeModel) ([]RevisionChange, error) {
+	olderDoc, err := toDocument(older)
+	if err != nil {
+		return nil, err
+	}
+	newerDoc, err := toDocument(newer)
+	if err != nil {
+		return nil, err
+	}
+	for field := range revisionBookkeepingFields {
+		delete(olderDoc, field)
+		delete(newerDoc, field)
+	}
+
+	changes := []RevisionChange{}
+	diffValues("", olderDoc, newerDoc, &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// diffValues baja por objetos y arrays (por índice) y anota solo las hojas que cambian
+func diffValues(path string, older, newer any, changes *[]RevisionChange) {
+	switch olderTyped := older.(type) {
+	case map[string]any:
+		if newerTyped, ok := newer.(map[string]any); ok {
+			for key, value := range olderTyped {
+				if next, found := newerTyped[key]; found {
+					diffValues(joinDiffPath(path, key), value, next, changes)
+				} else {
+					*changes = append(*changes, RevisionChange{Path: joinDiffPath(path, key), Op: "removed", From: value})
+				}
+			}
+			for key, value := range newerTyped {
+				if _, found := olderTyped[key]; !found {
+					*changes = append(*changes, RevisionChange{Path: joinDiffPath(path, key), Op: "added", To: value})
+				}
+			}
+			return
+		}
+
+	case []any:
+		if newerTyped, ok := newer.([]any); ok {
+			for i := 0; i < len(olderTyped) || i < len(newerTyped); i++ {
+				itemPath := joinDiffPath(path, strconv.Itoa(i))
+				switch {
+				case i >= len(newerTyped):
+					*changes = append(*changes, RevisionChange{Path: itemPath, Op: "removed", From: olderTyped[i]})
+				case i >= len(olderTyped):
+					*changes = append(*changes, RevisionChange{Path: itemPath, Op: "added", To: newerTyped[i]})
+				default:
+					diffValues(itemPath, olderTyped[i], newerTyped[i], changes)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(older, newer) {
+		*changes = append(*changes, RevisionChange{Path: path, Op: "changed", From: older, To: newer})
+	}
+}
+
+func joinDiffPath(path string, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return fmt.Sprintf("%s.%s", path, segment)
+}
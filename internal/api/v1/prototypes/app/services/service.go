@@ -4,21 +4,28 @@ import (
 	"mocky/internal/api/v1/prototypes/domain/repositories"
 	validator_controller "mocky/internal/context/controllers"
 	"mocky/internal/context/controllers/placeholder"
+	"sync"
 )
 
 type PrototypesService struct {
 	prototypesRepository  repositories.RepositoryPrototypes
+	revisionsRepository   repositories.RepositoryPrototypeRevisions
 	validator             *validator_controller.ValidatorRequest
 	placeholderController *placeholder.PlaceholderController
+
+	// prototypes compilados por ID (ver compiledFor)
+	compiled sync.Map
 }
 
 func NewPrototypesService(
 	prototypesRepository repositories.RepositoryPrototypes,
+	revisionsRepository repositories.RepositoryPrototypeRevisions,
 	validator *validator_controller.ValidatorRequest,
 	placeholderController *placeholder.PlaceholderController,
 ) *PrototypesService {
 	return &PrototypesService{
 		prototypesRepository:  prototypesRepository,
+		revisionsRepository:   revisionsRepository,
 		validator:             validator,
 		placeholderController: placeholderController,
 	}
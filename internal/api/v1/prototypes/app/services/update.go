@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Update reemplaza un prototype existente; pasa por las mismas revisiones que Create
+func (s *PrototypesService) Update(cc *customctx.CustomContext, command commands.UpdatePrototypeCommand) utils.Response[prototypes.PrototypeModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Updating prototype %s", command.ID)
+
+	current := s.prototypesRepository.Find(cc.Context(), command.ID)
+	if current.Err != nil {
+		entry.Error(current.Err.Error())
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(current.Err),
+			StatusCode: current.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	prototypeEntity := command.ToEntity()
+
+	prototypeModel := prototypes.PrototypeModel{
+		ID:        current.Data.ID,
+		CreatedAt: current.Data.CreatedAt,
+		Request:   prototypeEntity.Request,
+		Response:  prototypeEntity.Response,
+		Name:      prototypeEntity.Name,
+		Group:     prototypeEntity.Group,
+		Overrides: prototypeEntity.Overrides,
+	}
+
+	compiled, alert, err := s.prepare(cc, prototypeModel, "prototypes.update")
+	if err != nil {
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      err,
+			StatusCode: err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	if prototypeModel.Request.BodySchema != nil && prototypeModel.Request.BodySchema.TypeSchema == "" {
+		prototypeModel.Request.BodySchema = nil
+	}
+
+	if err := s.prototypesRepository.Update(cc.Context(), prototypeModel); err != nil {
+		entry.Error(err.Error())
+		code := http.StatusInternalServerError
+		if customErr, ok := err.(cerrs.CustomErrorInterface); ok {
+			code = customErr.GetCode()
+		}
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(code, err.Error(), "prototypes.update")),
+			StatusCode: code,
+			Success:    false,
+		}
+	}
+
+	prototypeModel = s.storeCompiled(cc, prototypeModel, compiled)
+	s.recordRevision(cc, prototypeModel, prototypes.RevisionUpdate, 0)
+
+	return utils.Response[prototypes.PrototypeModel]{
+		Data:       prototypeModel,
+		StatusCode: http.StatusOK,
+		Alert:      alert,
+		Success:    true,
+	}
+}
+
+// Patch aplica cambios sueltos sobre el prototype vigente; el resultado pasa por Update,
+// así que se revisa igual que un PUT completo
+func (s *PrototypesService) Patch(cc *customctx.CustomContext, command commands.PatchPrototypeCommand) utils.Response[prototypes.PrototypeModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Patching prototype %s", command.ID)
+
+	current := s.prototypesRepository.Find(cc.Context(), command.ID)
+	if current.Err != nil {
+		entry.Error(current.Err.Error())
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(current.Err),
+			StatusCode: current.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	merged, err := mergePatch(current.Data, command)
+	if err == nil {
+		err = merged.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		return utils.Response[prototypes.PrototypeModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "prototypes.patch")),
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+		}
+	}
+
+	return s.Update(cc, merged)
+}
+
+// mergePatch aplica las rutas del command sobre el prototype actual y devuelve el prototype
+// completo. Una ruta que no existe en el prototype (p. ej. "response.bdy") se rechaza.
+// Con MarkOverrides las rutas modificadas se agregan a overrides, para que un re-import no las pise.
+func mergePatch(current prototypes.PrototypeModel, command commands.PatchPrototypeCommand) (commands.UpdatePrototypeCommand, error) {
+
+	var doc map[string]any
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return commands.UpdatePrototypeCommand{}, err
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return commands.UpdatePrototypeCommand{}, err
+	}
+	for field := range doc {
+		if !commands.PatchableFields[field] {
+			delete(doc, field)
+		}
+	}
+
+	paths := make([]string, 0, len(command.Updates))
+	for path := range command.Updates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := setPath(doc, strings.Split(path, "."), command.Updates[path]); err != nil {
+			return commands.UpdatePrototypeCommand{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if command.MarkOverrides {
+		doc["overrides"] = markedOverrides(doc["overrides"], paths)
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return commands.UpdatePrototypeCommand{}, err
+	}
+
+	var merged commands.UpdatePrototypeCommand
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&merged); err != nil {
+		return commands.UpdatePrototypeCommand{}, errors.New("patched prototype is invalid: " + err.Error())
+	}
+	merged.ID = current.ID
+
+	return merged, nil
+}
+
+// setPath asigna value en la ruta; crea los objetos intermedios que falten
+func setPath(node any, segments []string, value any) error {
+	last := len(segments) == 1
+
+	switch typed := node.(type) {
+	case map[string]any:
+		if last {
+			typed[segments[0]] = value
+			return nil
+		}
+		next, ok := typed[segments[0]]
+		if !ok || next == nil {
+			next = map[string]any{}
+			typed[segments[0]] = next
+		}
+		return setPath(next, segments[1:], value)
+
+	case []any:
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(typed) {
+			return fmt.Errorf("index %q out of range (array of %d)", segments[0], len(typed))
+		}
+		if last {
+			typed[index] = value
+			return nil
+		}
+		return setPath(typed[index], segments[1:], value)
+	}
+
+	return fmt.Errorf("cannot set %q: parent value is %T, not an object or array", segments[0], node)
+}
+
+// markedOverrides agrega las rutas a la lista de overrides, sin repetir
+func markedOverrides(current any, paths []string) []any {
+	marked := []any{}
+	seen := map[string]bool{}
+	if list, ok := current.([]any); ok {
+		for _, item := range list {
+			if path, ok := item.(string); ok && !seen[path] {
+				seen[path] = true
+				marked = append(marked, path)
+			}
+		}
+	}
+	for _, path := range paths {
+		if strings.SplitN(path, ".", 2)[0] == "overrides" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		marked = append(marked, path)
+	}
+	return marked
+}
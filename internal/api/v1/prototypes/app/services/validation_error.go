@@ -0,0 +1,33 @@
+package services
+
+import (
+	"common/utils/cerrs"
+	"fmt"
+	validator_controller "mocky/internal/context/controllers"
+	"net/http"
+)
+
+// ValidationFailedError agrupa todos los errores de validación de una request al mock
+// (o del contrato de su respuesta); el controller los presenta según request.error_format.
+type ValidationFailedError struct {
+	*cerrs.CustomError
+	Format string
+	Errors []validator_controller.ValidationError
+}
+
+func newValidationFailedError(format string, errs []validator_controller.ValidationError) *ValidationFailedError {
+	return &ValidationFailedError{
+		CustomError: cerrs.NewCustomError(http.StatusUnprocessableEntity, fmt.Sprintf("request validation failed (%d errors)", len(errs)), "validate_request"),
+		Format:      format,
+		Errors:      errs,
+	}
+}
+
+// newContractViolationError: la respuesta renderizada no cumple response.schema (modo strict)
+func newContractViolationError(status int, scope string, format string, errs []validator_controller.ValidationError) *ValidationFailedError {
+	return &ValidationFailedError{
+		CustomError: cerrs.NewCustomError(status, fmt.Sprintf("response does not match its contract (%d errors)", len(errs)), scope),
+		Format:      format,
+		Errors:      errs,
+	}
+}
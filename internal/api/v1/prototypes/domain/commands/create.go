@@ -6,6 +6,9 @@ type CreatePrototypeCommand struct {
 	Request  entities.RequestEntity  `json:"request" binding:"required"`
 	Response entities.ResponseEntity `json:"response" binding:"required"`
 	Name     string                  `json:"name" binding:"required"`
+	Group    string                  `json:"group"`
+
+	Overrides []string `json:"overrides"`
 }
 
 func (c CreatePrototypeCommand) Validate() error {
@@ -15,7 +18,10 @@ func (c CreatePrototypeCommand) Validate() error {
 func (c CreatePrototypeCommand) ToEntity() entities.PrototypeEntity {
 	return entities.PrototypeEntity{
 		Name:     c.Name,
+		Group:    c.Group,
 		Request:  c.Request,
 		Response: c.Response,
+
+		Overrides: c.Overrides,
 	}
 }
@@ -0,0 +1,15 @@
+package commands
+
+// ImportPrototypesCommand: prototypes de un bundle; se guardan todos o ninguno
+type ImportPrototypesCommand struct {
+	Prototypes []CreatePrototypeCommand `json:"prototypes" binding:"required"`
+
+	// KeepOverrides conserva las rutas marcadas como overrides en los prototypes que se reemplazan
+	KeepOverrides bool `json:"keep_overrides"`
+	// Warnings son avisos de la conversión (p. ej. operaciones de un OpenAPI que se omitieron)
+	Warnings []string `json:"warnings"`
+}
+
+func (c ImportPrototypesCommand) Validate() error {
+	return nil
+}
@@ -0,0 +1,14 @@
+package commands
+
+import "common/domain/criteria"
+
+// ListPrototypesCommand: filtros y orden sobre los campos del repositorio, más la página pedida
+type ListPrototypesCommand struct {
+	Criteria criteria.Criteria
+	Offset   int
+	Limit    int
+}
+
+func (c ListPrototypesCommand) Validate() error {
+	return nil
+}
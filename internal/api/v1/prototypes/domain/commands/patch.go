@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PatchableFields son los campos del prototype que se pueden modificar con PATCH
+var PatchableFields = map[string]bool{"request": true, "response": true, "name": true, "group": true, "overrides": true}
+
+// PatchPrototypeCommand actualiza campos sueltos de un prototype con rutas punteadas
+// ("response.body.name"); el service las aplica sobre el prototype vigente. Con
+// MarkOverrides las rutas modificadas se agregan a overrides.
+type PatchPrototypeCommand struct {
+	ID            string
+	Updates       map[string]any
+	MarkOverrides bool
+}
+
+func (c PatchPrototypeCommand) Validate() error {
+
+	if len(c.Updates) == 0 {
+		return errors.New("at least one field is required")
+	}
+
+	for path := range c.Updates {
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return errors.New("invalid path " + strconv.Quote(path))
+			}
+		}
+		if !PatchableFields[segments[0]] {
+			return errors.New(strconv.Quote(path) + " cannot be updated (allowed: request, response, name, group, overrides)")
+		}
+	}
+
+	return nil
+}
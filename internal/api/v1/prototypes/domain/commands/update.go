@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
+
+// UpdatePrototypeCommand reemplaza un prototype completo (PUT y PATCH ya aplicado)
+type UpdatePrototypeCommand struct {
+	ID       string                  `json:"id" binding:"required"`
+	Request  entities.RequestEntity  `json:"request" binding:"required"`
+	Response entities.ResponseEntity `json:"response" binding:"required"`
+	Name     string                  `json:"name" binding:"required"`
+	Group    string                  `json:"group"`
+
+	Overrides []string `json:"overrides"`
+}
+
+// Validate revisa lo que el compilado del service no cubre; el PUT ya llega validado por
+// el DTO, pero el resultado de un PATCH se arma en el service
+func (c UpdatePrototypeCommand) Validate() error {
+
+	if c.Request.Method == "" {
+		return errors.New("request is invalid: method is required")
+	}
+
+	if c.Request.UrlPath == "" {
+		return errors.New("request is invalid: urlPath is required")
+	}
+
+	if schema := c.Request.BodySchema; schema != nil && schema.TypeSchema != "" && schema.TypeSchema != "object" && schema.TypeSchema != "array" {
+		return errors.New("request is invalid: bodySchema is invalid: type_schema must be 'object' or 'array'")
+	}
+
+	switch c.Request.ErrorFormat {
+	case "", entities.ErrorFormatDefault, entities.ErrorFormatSimple, entities.ErrorFormatProblem:
+	default:
+		return errors.New("request is invalid: error_format must be 'default', 'simple' or 'problem'")
+	}
+
+	for _, path := range c.Overrides {
+		segments := strings.Split(path, ".")
+		if !PatchableFields[segments[0]] || segments[0] == "overrides" {
+			return errors.New("overrides is invalid: " + strconv.Quote(path) + " is not a prototype field (allowed: request, response, name, group)")
+		}
+	}
+
+	return nil
+}
+
+func (c UpdatePrototypeCommand) ToEntity() entities.PrototypeEntity {
+	return entities.PrototypeEntity{
+		Name:     c.Name,
+		Group:    c.Group,
+		Request:  c.Request,
+		Response: c.Response,
+
+		Overrides: c.Overrides,
+	}
+}
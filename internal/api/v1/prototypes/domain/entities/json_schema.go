@@ -0,0 +1,120 @@
+package entities
+
+import "strings"
+
+// JSONSchema traduce el bodySchema a JSON Schema 2020-12 (el formato de request.jsonSchema)
+func (b BodySchemaEntity) JSONSchema() map[string]any {
+	if strings.ToLower(b.TypeSchema) == "array" {
+		return b.AsArrayProperty().JSONSchema(true)
+	}
+	return b.AsObjectProperty().JSONSchema(b.AditionalProperties)
+}
+
+// JSONSchema traduce la propiedad a JSON Schema 2020-12. parentAdditional es el
+// additional_properties heredado del objeto padre. Con composición el objeto base no
+// cierra sus propiedades: las de las ramas se combinan con las suyas al validar.
+func (p PropertyEntity) JSONSchema(parentAdditional bool) map[string]any {
+	schema := map[string]any{}
+
+	typ := strings.ToLower(p.Type)
+	switch {
+	case typ != "" && p.Nullable:
+		schema["type"] = []any{typ, "null"}
+	case typ != "":
+		schema["type"] = typ
+	}
+
+	if p.MinLength > 0 {
+		schema["minLength"] = p.MinLength
+	}
+	if p.MaxLength > 0 {
+		schema["maxLength"] = p.MaxLength
+	}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if p.Pattern != "" {
+		schema["pattern"] = p.Pattern
+	}
+
+	for keyword, value := range map[string]*float64{
+		"minimum":          p.Minimum,
+		"maximum":          p.Maximum,
+		"exclusiveMinimum": p.ExclusiveMinimum,
+		"exclusiveMaximum": p.ExclusiveMaximum,
+		"multipleOf":       p.MultipleOf,
+	} {
+		if value != nil {
+			schema[keyword] = *value
+		}
+	}
+
+	additional := parentAdditional
+	if p.AdditionalProperties != nil {
+		additional = *p.AdditionalProperties
+	}
+
+	if p.Items != nil {
+		schema["items"] = p.Items.JSONSchema(additional)
+	}
+	if p.MinItems > 0 {
+		schema["minItems"] = p.MinItems
+	}
+	if p.MaxItems > 0 {
+		schema["maxItems"] = p.MaxItems
+	}
+	if p.UniqueItems {
+		schema["uniqueItems"] = true
+	}
+
+	if typ == "object" || len(p.Properties) > 0 {
+		properties := make(map[string]any, len(p.Properties))
+		var required []any
+		for _, child := range p.Properties {
+			properties[child.Name] = child.JSONSchema(additional)
+			if child.IsRequired {
+				required = append(required, child.Name)
+			}
+		}
+		if len(properties) > 0 {
+			schema["properties"] = properties
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		if !additional && !p.HasComposition() {
+			schema["additionalProperties"] = false
+		}
+	}
+
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	if p.Const != nil {
+		schema["const"] = p.Const
+	}
+	if p.Default != nil {
+		schema["default"] = p.Default
+	}
+
+	for keyword, branches := range map[string][]PropertyEntity{"oneOf": p.OneOf, "anyOf": p.AnyOf, "allOf": p.AllOf} {
+		if len(branches) == 0 {
+			continue
+		}
+		translated := make([]any, 0, len(branches))
+		for _, branch := range branches {
+			// las ramas solo cierran sus propiedades si lo declaran ellas mismas
+			branchSchema := branch.JSONSchema(true)
+			if branch.Name != "" {
+				branchSchema["title"] = branch.Name
+			}
+			translated = append(translated, branchSchema)
+		}
+		schema[keyword] = translated
+	}
+	if p.Discriminator != nil {
+		schema["discriminator"] = map[string]any{"propertyName": p.Discriminator.PropertyName}
+	}
+
+	return schema
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "strings"
+
 /*
 {
     "request": {
@@ -79,25 +81,97 @@ package entities
 
 type PrototypeEntity struct {
 	Name     string         `json:"name" binding:"required"`
+	Group    string         `json:"group"`
 	Request  RequestEntity  `json:"request" binding:"required"`
 	Response ResponseEntity `json:"response" binding:"required"`
+
+	// Rutas punteadas editadas a mano ("response.body.name"); un re-import no las pisa
+	Overrides []string `json:"overrides,omitempty"`
 }
 
 type RequestEntity struct {
-	Method     string            `json:"method" binding:"required"`
-	UrlPath    string            `json:"urlPath" binding:"required"`
-	PathParams map[string]string `json:"path_params"`
-	Headers    map[string]string `json:"headers"`
-	BodySchema *BodySchemaEntity `json:"bodySchema"`
+	Method         string                         `json:"method" binding:"required"`
+	UrlPath        string                         `json:"urlPath" binding:"required"`
+	PathParams     map[string]string              `json:"path_params"`
+	Headers        map[string]string              `json:"headers"`
+	HeaderMatchers map[string]ValuesMatcherEntity `json:"header_matchers"`
+	QueryMatchers  map[string]ValuesMatcherEntity `json:"query_matchers"`
+	QuerySchema    []PropertyEntity               `json:"query_schema"`  // query params tipados (mismas reglas que el body)
+	HeaderSchema   []PropertyEntity               `json:"header_schema"` // headers tipados
+	BodySchema     *BodySchemaEntity              `json:"bodySchema"`
+	JSONSchema     map[string]any                 `json:"jsonSchema"`   // JSON Schema estándar (draft 2020-12)
+	ErrorFormat    string                         `json:"error_format"` // default | simple | problem (RFC 7807)
 
 	Delay int `json:"delay"`
 }
 
+// Formatos de la respuesta 422 cuando la request no pasa la validación
+const (
+	ErrorFormatDefault = "default" // envelope habitual + validation_errors
+	ErrorFormatSimple  = "simple"  // {"errors": [...]}
+	ErrorFormatProblem = "problem" // RFC 7807, application/problem+json
+)
+
+// Modos de ValuesMatcherEntity.Match
+const (
+	MatchAnyValue  = "any"
+	MatchAllValues = "all"
+)
+
+// ValuesMatcherEntity valida parámetros que pueden repetirse (?tag=a&tag=b, Accept, Cookie).
+// Pattern sigue la misma convención que Headers: exacto, o regex si empieza con "^".
+type ValuesMatcherEntity struct {
+	Pattern  string `json:"pattern"`
+	Match    string `json:"match"` // any (default) | all
+	Count    *int   `json:"count"`
+	MinCount *int   `json:"min_count"`
+	MaxCount *int   `json:"max_count"`
+}
+
 type BodySchemaEntity struct {
 	Name                string           `json:"name" binding:"required"`
-	TypeSchema          string           `json:"type_schema" binding:"required"`
+	TypeSchema          string           `json:"type_schema" binding:"required"` // object | array
 	AditionalProperties bool             `json:"aditional_properties"`
 	Properties          []PropertyEntity `json:"properties"`
+
+	// Solo para type_schema "array" (endpoints bulk)
+	Items       *PropertyEntity `json:"items,omitempty"`
+	MinItems    int32           `json:"min_items,omitempty"`
+	MaxItems    int32           `json:"max_items,omitempty"`
+	UniqueItems bool            `json:"unique_items,omitempty"`
+
+	// Composición (payloads polimórficos)
+	OneOf         []PropertyEntity     `json:"one_of,omitempty"`
+	AnyOf         []PropertyEntity     `json:"any_of,omitempty"`
+	AllOf         []PropertyEntity     `json:"all_of,omitempty"`
+	Discriminator *DiscriminatorEntity `json:"discriminator,omitempty"`
+}
+
+// AsObjectProperty expresa un root "object" como una PropertyEntity para reusar su validador
+func (b BodySchemaEntity) AsObjectProperty() PropertyEntity {
+	additional := b.AditionalProperties
+	return PropertyEntity{
+		Name:                 b.Name,
+		Type:                 "object",
+		Properties:           b.Properties,
+		AdditionalProperties: &additional,
+		OneOf:                b.OneOf,
+		AnyOf:                b.AnyOf,
+		AllOf:                b.AllOf,
+		Discriminator:        b.Discriminator,
+	}
+}
+
+// AsArrayProperty expresa un root "array" como una PropertyEntity para reusar su validador
+func (b BodySchemaEntity) AsArrayProperty() PropertyEntity {
+	return PropertyEntity{
+		Name:        b.Name,
+		Type:        "array",
+		Items:       b.Items,
+		MinItems:    b.MinItems,
+		MaxItems:    b.MaxItems,
+		UniqueItems: b.UniqueItems,
+	}
 }
 
 type PropertyEntity struct {
@@ -109,8 +183,130 @@ type PropertyEntity struct {
 	Format     string           `json:"format"`
 	Pattern    string           `json:"pattern"`
 	Properties []PropertyEntity `json:"properties"`
+
+	// type "array": schema de cada elemento (escalar u objeto) y límites
+	Items       *PropertyEntity `json:"items,omitempty"`
+	MinItems    int32           `json:"min_items,omitempty"`
+	MaxItems    int32           `json:"max_items,omitempty"`
+	UniqueItems bool            `json:"unique_items,omitempty"`
+
+	// type "number" / "integer"
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusive_minimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusive_maximum,omitempty"`
+	MultipleOf       *float64 `json:"multiple_of,omitempty"`
+
+	// cualquier tipo
+	Enum     []any `json:"enum,omitempty"`
+	Const    any   `json:"const,omitempty"`
+	Nullable bool  `json:"nullable,omitempty"`
+	// Default se agrega al body (y queda disponible en los templates) cuando el campo no llega
+	Default any `json:"default,omitempty"`
+
+	// type "object": nil hereda el valor del objeto padre
+	AdditionalProperties *bool `json:"additional_properties,omitempty"`
+
+	// Composición: cada rama se valida contra el mismo valor
+	OneOf         []PropertyEntity     `json:"one_of,omitempty"`
+	AnyOf         []PropertyEntity     `json:"any_of,omitempty"`
+	AllOf         []PropertyEntity     `json:"all_of,omitempty"`
+	Discriminator *DiscriminatorEntity `json:"discriminator,omitempty"`
+}
+
+// DiscriminatorEntity elige la rama de one_of/any_of según el valor de un campo.
+// Mapping traduce valor -> nombre de rama; sin mapping el valor es el nombre de la rama.
+type DiscriminatorEntity struct {
+	PropertyName string            `json:"property_name"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// HasComposition indica si la propiedad usa one_of, any_of o all_of
+func (p PropertyEntity) HasComposition() bool {
+	return len(p.OneOf) > 0 || len(p.AnyOf) > 0 || len(p.AllOf) > 0
 }
 
 type ResponseEntity struct {
-	Body map[string]any `json:"body"`
+	StatusCode int               `json:"statusCode"` // default 200
+	Headers    map[string]string `json:"headers"`
+	Body       any               `json:"body"` // objeto, array o cualquier valor JSON; un string con Content-Type no JSON se entrega como texto
+
+	// Contrato del body de respuesta: se revisa al crear (con datos de ejemplo) y en cada llamada
+	Schema       *BodySchemaEntity `json:"schema"`
+	JSONSchema   map[string]any    `json:"jsonSchema"`
+	ContractMode string            `json:"contract_mode"` // warn (default) | strict
+}
+
+// Modos de ResponseEntity.ContractMode
+const (
+	ContractModeWarn   = "warn"   // header X-Mocky-Contract-Warning + log, la respuesta se entrega
+	ContractModeStrict = "strict" // la respuesta que no cumple el contrato se cambia por un 500
+)
+
+// HasContract indica si la respuesta declara un schema contra el cual revisarse
+func (r ResponseEntity) HasContract() bool {
+	return (r.Schema != nil && r.Schema.TypeSchema != "") || r.JSONSchema != nil
+}
+
+// IsStrictContract indica si una respuesta fuera de contrato debe convertirse en error
+func (r ResponseEntity) IsStrictContract() bool {
+	return r.ContractMode == ContractModeStrict
+}
+
+// IsTemplatePath indica si el urlPath tiene segmentos dinámicos (/v1/users/:id o /v1/users/{id})
+func (r RequestEntity) IsTemplatePath() bool {
+	for _, segment := range strings.Split(r.UrlPath, "/") {
+		if _, ok := pathParamName(segment); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPath compara el path recibido contra el urlPath del prototipo y extrae
+// los segmentos dinámicos. specificity cuenta los segmentos literales, para
+// preferir /v1/users/me sobre /v1/users/:id.
+func (r RequestEntity) MatchPath(path string) (params map[string]string, specificity int, ok bool) {
+	templateSegments := strings.Split(strings.Trim(r.UrlPath, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return nil, 0, false
+	}
+
+	params = map[string]string{}
+	for i, segment := range templateSegments {
+		if name, isParam := pathParamName(segment); isParam {
+			if pathSegments[i] == "" {
+				return nil, 0, false
+			}
+			params[name] = pathSegments[i]
+			continue
+		}
+		if segment != pathSegments[i] {
+			return nil, 0, false
+		}
+		specificity++
+	}
+	return params, specificity, true
+}
+
+// PathParamNames devuelve los segmentos dinámicos del urlPath, en orden
+func (r RequestEntity) PathParamNames() []string {
+	var names []string
+	for _, segment := range strings.Split(strings.Trim(r.UrlPath, "/"), "/") {
+		if name, ok := pathParamName(segment); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func pathParamName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+		return segment[1:], true
+	}
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && len(segment) > 2 {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
 }
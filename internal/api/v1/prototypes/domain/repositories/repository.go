@@ -16,11 +16,21 @@ type RepositoryPrototypes interface {
 	UpdateFields(ctx context.Context, id string, updates map[string]interface{}) utils.Result[prototypes.PrototypeModel]
 
 	Delete(ctx context.Context, id string) error
+	DeleteAll(cc *customctx.CustomContext) utils.Result[int64]
 
 	Find(ctx context.Context, id string) utils.Result[prototypes.PrototypeModel]
 	FindAll(ctx context.Context) utils.Result[[]prototypes.PrototypeListModel]
 
 	Matching(cr criteria.Criteria, tableName string, offset int, limit int) utils.Result[[]prototypes.PrototypeListModel]
+	CountMatching(cr criteria.Criteria, tableName string) utils.Result[int64]
 	GetByPath(cc *customctx.CustomContext, urlPath string, method string) utils.Result[prototypes.PrototypeModel]
 	SaveOrUpdate(cc *customctx.CustomContext, document prototypes.PrototypeModel) utils.Result[string]
 }
+
+// RepositoryPrototypeRevisions guarda el historial de cambios de cada prototype; Append
+// asigna el número de revisión (1, 2, 3… por prototype) y la fecha
+type RepositoryPrototypeRevisions interface {
+	Append(ctx context.Context, revision prototypes.PrototypeRevisionModel) utils.Result[prototypes.PrototypeRevisionModel]
+	List(ctx context.Context, prototypeID string) utils.Result[[]prototypes.PrototypeRevisionListModel]
+	FindRevision(ctx context.Context, prototypeID string, revision int) utils.Result[prototypes.PrototypeRevisionModel]
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"io"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PrototypesController) Import(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Importing prototypes")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		respondUnprocessable(ctx, cc, err, "dto.validate.ImportPrototypesDTO")
+		return
+	}
+
+	dto, err := dtos.DecodeImportPrototypesDTO(body, dtos.BundleFormat(ctx.Query("format"), ctx.ContentType()))
+	if err == nil {
+		err = dto.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.ImportPrototypesDTO")
+		return
+	}
+
+	response := c.prototypesService.Import(cc, dto.ToCommand())
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *PrototypesController) Export(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Exporting prototypes")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	// mismos filtros que el listado; format, offset y limit no aplican
+	query := ctx.Request.URL.Query()
+	format := dtos.BundleFormat(query.Get("format"), ctx.GetHeader("Accept"))
+	query.Del("format")
+	query.Del("offset")
+	query.Del("limit")
+
+	dto, err := dtos.NewListPrototypesDTO(query)
+	if err == nil {
+		err = dto.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.ListPrototypesDTO")
+		return
+	}
+
+	response := c.prototypesService.Export(cc, dto.ToCommand())
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	bundle, err := dtos.NewExportBundleDTO(response.Results, time.Now()).Encode(format)
+	if err != nil {
+		entry.Error(err.Error())
+		failed := utils.Response[any]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "prototypes.export.encode")),
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+		ctx.JSON(failed.StatusCode, failed.ToMapWithCustomContext(cc))
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == dtos.BundleFormatYAML {
+		contentType = "application/yaml; charset=utf-8"
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="prototypes.`+format+`"`)
+	ctx.Data(http.StatusOK, contentType, bundle)
+}
+
+// importConverted guarda los prototypes generados desde otro formato (todos o ninguno),
+// conservando los overrides de los que ya existían
+func (c *PrototypesController) importConverted(ctx *gin.Context, cc *customctx.CustomContext, source, scope string, bundle dtos.ImportPrototypesDTO, warnings []string) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	for _, warning := range warnings {
+		entry.Warnf("%s import: %s", source, warning)
+	}
+	if err := bundle.Validate(); err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, scope)
+		return
+	}
+
+	command := bundle.ToCommand()
+	command.KeepOverrides = true
+	command.Warnings = warnings
+
+	response := c.prototypesService.Import(cc, command)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
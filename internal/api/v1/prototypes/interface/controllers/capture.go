@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"io"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportPostman genera prototypes de una colección de Postman v2.1: uno por ejemplo
+// guardado, agrupados por carpeta y sin repetir rutas.
+func (c *PrototypesController) ImportPostman(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Importing Postman collection")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		respondUnprocessable(ctx, cc, err, "dto.validate.PostmanCollectionDTO")
+		return
+	}
+
+	collection, err := dtos.DecodePostmanCollectionDTO(body)
+	if err == nil {
+		err = collection.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.PostmanCollectionDTO")
+		return
+	}
+
+	bundle, warnings := collection.ToImportPrototypesDTO(ctx.Query("group"))
+	c.importConverted(ctx, cc, "Postman", "dto.validate.PostmanCollectionDTO", bundle, warnings)
+}
+
+// ImportHAR genera prototypes de una captura HAR 1.2 (DevTools): uno por llamada a API,
+// agrupados por host y sin repetir rutas.
+func (c *PrototypesController) ImportHAR(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Importing HAR capture")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		respondUnprocessable(ctx, cc, err, "dto.validate.HARDocumentDTO")
+		return
+	}
+
+	document, err := dtos.DecodeHARDocumentDTO(body)
+	if err == nil {
+		err = document.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.HARDocumentDTO")
+		return
+	}
+
+	bundle, warnings := document.ToImportPrototypesDTO(ctx.Query("group"))
+	c.importConverted(ctx, cc, "HAR", "dto.validate.HARDocumentDTO", bundle, warnings)
+}
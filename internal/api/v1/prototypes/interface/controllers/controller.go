@@ -1,6 +1,14 @@
 package controllers
 
-import "mocky/internal/api/v1/prototypes/app/services"
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"mocky/internal/api/v1/prototypes/app/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
 
 type PrototypesController struct {
 	prototypesService *services.PrototypesService
@@ -11,3 +19,13 @@ func NewPrototypesController(prototypesService *services.PrototypesService) *Pro
 		prototypesService: prototypesService,
 	}
 }
+
+// respondUnprocessable responde 422 para entradas que no pasan por GetDTOWithResponse (query string, YAML)
+func respondUnprocessable(ctx *gin.Context, cc *customctx.CustomContext, err error, scope string) {
+	response := utils.Response[any]{
+		Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), scope)),
+		StatusCode: http.StatusUnprocessableEntity,
+		Success:    false,
+	}
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -26,6 +26,5 @@ func (c *PrototypesController) Create(ctx *gin.Context) {
 
 	response := c.prototypesService.Create(cc, command)
 
-	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
-
+	renderSaved(ctx, cc, response)
 }
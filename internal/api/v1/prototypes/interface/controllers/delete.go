@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PrototypesController) Delete(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Deleting prototype")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.prototypesService.Delete(cc, ctx.Param("id"))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *PrototypesController) DeleteAll(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Deleting all prototypes")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.prototypesService.DeleteAll(cc)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -3,6 +3,10 @@ package controllers
 import (
 	"common/domain/customctx"
 	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+	"net/url"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,7 +19,39 @@ func (c *PrototypesController) List(ctx *gin.Context) {
 
 	cc := customctx.NewCustomContext(ctx.Request.Context())
 
-	prototypes := c.prototypesService.List(cc)
+	dto, err := dtos.NewListPrototypesDTO(ctx.Request.URL.Query())
+	if err == nil {
+		err = dto.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.ListPrototypesDTO")
+		return
+	}
+
+	prototypes := c.prototypesService.List(cc, dto.ToCommand())
+
+	if page := prototypes.Pagination; page != nil {
+		page.Next, page.Prev = pageLinks(ctx.Request.URL, page)
+	}
 
 	ctx.JSON(prototypes.StatusCode, prototypes.ToMapWithCustomContext(cc))
 }
+
+// pageLinks arma los links a la página siguiente y anterior conservando los filtros y el orden
+func pageLinks(current *url.URL, page *utils.Pagination) (next string, prev string) {
+	link := func(offset int) string {
+		query := current.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(page.Limit))
+		return current.Path + "?" + query.Encode()
+	}
+
+	if int64(page.Offset+page.Limit) < page.Total {
+		next = link(page.Offset + page.Limit)
+	}
+	if page.Offset > 0 {
+		prev = link(max(page.Offset-page.Limit, 0))
+	}
+	return next, prev
+}
@@ -3,7 +3,12 @@ package controllers
 import (
 	"common/domain/customctx"
 	"common/domain/logger"
+	"errors"
+	middleware "mocky/internal/api/middlewares"
+	"mocky/internal/api/v1/prototypes/app/services"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,21 +21,21 @@ func extractPath(c *gin.Context) map[string]string {
 	return out
 }
 
-func extractHeaders(r *http.Request) map[string]string {
-	out := make(map[string]string)
+func extractHeaders(r *http.Request) map[string][]string {
+	out := make(map[string][]string)
 	for k, vals := range r.Header {
 		if len(vals) > 0 {
-			out[k] = vals[0] // primer valor
+			out[k] = append([]string(nil), vals...) // todos los valores
 		}
 	}
 	return out
 }
 
-func extractQuery(r *http.Request) map[string]string {
-	out := make(map[string]string)
+func extractQuery(r *http.Request) map[string][]string {
+	out := make(map[string][]string)
 	for k, vals := range r.URL.Query() {
 		if len(vals) > 0 {
-			out[k] = vals[0] // solo el primer valor
+			out[k] = vals // todos los valores (?tag=a&tag=b)
 		}
 	}
 	return out
@@ -48,13 +53,48 @@ func (c *PrototypesController) Mock(ctx *gin.Context) {
 	headers := extractHeaders(ctx.Request)
 	query := extractQuery(ctx.Request)
 
-	response := c.prototypesService.Mock(cc, ctx.Request, pathParams, headers, query)
+	receivedAt := ctx.GetTime(middleware.ReceivedAtKey)
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	response := c.prototypesService.Mock(cc, ctx.Request, pathParams, headers, query, receivedAt)
+
+	var validationFailed *services.ValidationFailedError
+	if errors.As(response.Error, &validationFailed) {
+		renderValidationErrors(ctx, cc, validationFailed)
+		return
+	}
 
 	if response.Error != nil {
 		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
 		return
 	}
 
-	ctx.JSON(response.StatusCode, response.Data)
+	if response.Data.ContractWarning != "" {
+		ctx.Header(services.ContractWarningHeader, response.Data.ContractWarning)
+	}
+
+	for name, value := range response.Data.Headers {
+		ctx.Header(name, value)
+	}
+
+	contentType := ctx.Writer.Header().Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	// un body string con Content-Type que no es JSON (text/plain, xml, ...) se entrega tal cual
+	if text, ok := response.Data.Body.(string); ok && !strings.Contains(strings.ToLower(contentType), "json") {
+		ctx.Data(response.StatusCode, contentType, []byte(text))
+		return
+	}
+
+	if response.Data.Rendered != nil {
+		ctx.Data(response.StatusCode, contentType, response.Data.Rendered)
+		return
+	}
+
+	ctx.JSON(response.StatusCode, response.Data.Body)
 
 }
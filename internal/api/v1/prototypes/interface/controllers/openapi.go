@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"io"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+	"mocky/internal/core/settings"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportOpenAPI genera un prototype por operación de un documento OpenAPI 3.0/3.1. Se
+// guardan todos o ninguno, y al re-importar se conservan los overrides de cada prototype.
+func (c *PrototypesController) ImportOpenAPI(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Importing OpenAPI document")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		respondUnprocessable(ctx, cc, err, "dto.validate.OpenAPIDocumentDTO")
+		return
+	}
+
+	document, err := dtos.DecodeOpenAPIDocumentDTO(body, dtos.BundleFormat(ctx.Query("format"), ctx.ContentType()))
+	if err == nil {
+		if basePath, ok := ctx.GetQuery("base_path"); ok {
+			document.BasePath = &basePath
+		}
+		document.Group = ctx.Query("group")
+		err = document.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.OpenAPIDocumentDTO")
+		return
+	}
+
+	bundle, warnings := document.ToImportPrototypesDTO()
+	c.importConverted(ctx, cc, "OpenAPI", "dto.validate.OpenAPIDocumentDTO", bundle, warnings)
+}
+
+// OpenAPI genera un documento OpenAPI 3.1 con los prototypes registrados (todos, o los
+// que pasan los filtros del listado). openapi.yaml responde YAML y openapi.json, JSON.
+func (c *PrototypesController) OpenAPI(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Generating OpenAPI document")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	format := dtos.BundleFormatYAML
+	if strings.HasSuffix(ctx.Request.URL.Path, ".json") {
+		format = dtos.BundleFormatJSON
+	}
+
+	query := ctx.Request.URL.Query()
+	query.Del("offset")
+	query.Del("limit")
+
+	dto, err := dtos.NewListPrototypesDTO(query)
+	if err == nil {
+		err = dto.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.ListPrototypesDTO")
+		return
+	}
+
+	response := c.prototypesService.Export(cc, dto.ToCommand())
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	serverURL := path.Join("/", settings.Settings.ROOT_PATH, "v1/mocky")
+	document, err := dtos.NewOpenAPIExportDTO(response.Results, serverURL, time.Now()).Encode(format)
+	if err != nil {
+		entry.Error(err.Error())
+		failed := utils.Response[any]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "prototypes.openapi.encode")),
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+		ctx.JSON(failed.StatusCode, failed.ToMapWithCustomContext(cc))
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == dtos.BundleFormatYAML {
+		contentType = "application/yaml; charset=utf-8"
+	}
+	ctx.Data(http.StatusOK, contentType, document)
+}
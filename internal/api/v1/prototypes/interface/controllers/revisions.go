@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"errors"
+	"mocky/internal/api/v1/prototypes/app/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorMiddleware anota en el contexto quién hace el cambio (header X-Mocky-Author o,
+// sin él, la IP del cliente) para el historial de revisiones
+func AuthorMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		author := strings.TrimSpace(ctx.GetHeader(services.AuthorHeader))
+		if author == "" {
+			author = ctx.ClientIP()
+		}
+		ctx.Request = ctx.Request.WithContext(services.WithAuthor(ctx.Request.Context(), author))
+		ctx.Next()
+	}
+}
+
+func (c *PrototypesController) Revisions(ctx *gin.Context) {
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.prototypesService.Revisions(cc, ctx.Param("id"))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *PrototypesController) Revision(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	revision, err := revisionNumber(ctx.Param("rev"), "rev")
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "prototypes.revisions.rev")
+		return
+	}
+
+	response := c.prototypesService.Revision(cc, ctx.Param("id"), revision)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+// DiffRevisions compara dos revisiones: ?from=2&to=5. Sin to se usa la última y sin from la anterior a to.
+func (c *PrototypesController) DiffRevisions(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	var from, to int
+	var err error
+	if value := ctx.Query("from"); value != "" {
+		from, err = revisionNumber(value, "from")
+	}
+	if value := ctx.Query("to"); value != "" && err == nil {
+		to, err = revisionNumber(value, "to")
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "prototypes.revisions.diff")
+		return
+	}
+
+	response := c.prototypesService.DiffRevisions(cc, ctx.Param("id"), from, to)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+// RestoreRevision vuelve el prototype a una revisión; responde como un PUT
+func (c *PrototypesController) RestoreRevision(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	revision, err := revisionNumber(ctx.Param("rev"), "rev")
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "prototypes.revisions.rev")
+		return
+	}
+
+	response := c.prototypesService.RestoreRevision(cc, ctx.Param("id"), revision)
+
+	renderSaved(ctx, cc, response)
+}
+
+func revisionNumber(value string, name string) (int, error) {
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 1 {
+		return 0, errors.New(name + " must be a revision number (1, 2, 3…)")
+	}
+	return revision, nil
+}
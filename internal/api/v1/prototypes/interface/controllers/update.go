@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"common/utils"
+	"errors"
+	"mocky/internal/api/v1/prototypes/app/services"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+	prototypes "mocky/internal/db/mongo/prototypes"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *PrototypesController) Update(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dto := cdtos.GetDTOWithResponse[dtos.UpdatePrototypeDTO](ctx, cc)
+	if dto.Error != nil {
+		entry.Error(dto.Error.Error())
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	command := dto.Data.ToCommand(ctx.Param("id"))
+
+	response := c.prototypesService.Update(cc, command)
+
+	renderSaved(ctx, cc, response)
+}
+
+func (c *PrototypesController) Patch(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dto := cdtos.GetDTOWithResponse[dtos.PatchPrototypeDTO](ctx, cc)
+	if dto.Error != nil {
+		entry.Error(dto.Error.Error())
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	// el resultado del patch se valida como un PUT completo; ?override=true marca las rutas como overrides
+	command := dto.Data.ToCommand(ctx.Param("id"), ctx.Query("override") == "true")
+
+	response := c.prototypesService.Patch(cc, command)
+
+	renderSaved(ctx, cc, response)
+}
+
+// renderSaved responde create/update: errores de contrato con su formato y el header de aviso en modo warn
+func renderSaved(ctx *gin.Context, cc *customctx.CustomContext, response utils.Response[prototypes.PrototypeModel]) {
+
+	var contractFailed *services.ValidationFailedError
+	if errors.As(response.Error, &contractFailed) {
+		renderValidationErrors(ctx, cc, contractFailed)
+		return
+	}
+
+	if response.Alert != nil && response.Alert.Code == services.ContractAlertCode {
+		ctx.Header(services.ContractWarningHeader, response.Alert.Message)
+	}
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
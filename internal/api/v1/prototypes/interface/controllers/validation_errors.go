@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"encoding/json"
+	"mocky/internal/api/v1/prototypes/app/services"
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validationErrorItem es la forma estable de cada error, igual en todos los formatos
+type validationErrorItem struct {
+	In      string `json:"in"`
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// renderValidationErrors responde 422 con todos los errores en el formato elegido por el prototype
+func renderValidationErrors(ctx *gin.Context, cc *customctx.CustomContext, failed *services.ValidationFailedError) {
+
+	items := make([]validationErrorItem, len(failed.Errors))
+	for i, err := range failed.Errors {
+		items[i] = validationErrorItem{
+			In:      err.Location(),
+			Pointer: err.JSONPointer(),
+			Code:    err.Code,
+			Message: err.Err,
+		}
+	}
+
+	status := failed.GetCode()
+
+	switch failed.Format {
+	case entities.ErrorFormatSimple:
+		ctx.JSON(status, gin.H{"errors": items})
+
+	case entities.ErrorFormatProblem:
+		// RFC 7807: los errores van como miembro de extensión
+		problem, _ := json.Marshal(gin.H{
+			"type":     "about:blank",
+			"title":    http.StatusText(status),
+			"status":   status,
+			"detail":   failed.Error(),
+			"instance": ctx.Request.URL.Path,
+			"errors":   items,
+			"trace_id": utils.GetFieldsOfLogger(cc.Context()).TraceID,
+		})
+		ctx.Data(status, "application/problem+json", problem)
+
+	default:
+		response := utils.Response[any]{
+			Error:      cc.NewError(failed.CustomError),
+			StatusCode: status,
+			Success:    false,
+		}
+		body := response.ToMapWithCustomContext(cc)
+		body["validation_errors"] = items
+		ctx.JSON(status, body)
+	}
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"encoding/json"
+	"io"
+	"mocky/internal/api/v1/prototypes/interface/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportWireMock genera un prototype por stub mapping de WireMock (uno suelto o el archivo
+// de /__admin/mappings). Se guardan todos o ninguno, y se conservan los overrides.
+func (c *PrototypesController) ImportWireMock(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Importing WireMock mappings")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		respondUnprocessable(ctx, cc, err, "dto.validate.WireMockMappingsDTO")
+		return
+	}
+
+	mappings, err := dtos.DecodeWireMockMappingsDTO(body)
+	if err == nil {
+		err = mappings.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.WireMockMappingsDTO")
+		return
+	}
+
+	bundle, warnings := mappings.ToImportPrototypesDTO(ctx.Query("group"))
+	c.importConverted(ctx, cc, "WireMock", "dto.validate.WireMockMappingsDTO", bundle, warnings)
+}
+
+// ExportWireMock devuelve los prototypes (todos, o los que pasan los filtros del listado)
+// como un archivo de mappings que WireMock carga con POST /__admin/mappings/import
+func (c *PrototypesController) ExportWireMock(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Exporting WireMock mappings")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	query := ctx.Request.URL.Query()
+	query.Del("offset")
+	query.Del("limit")
+
+	dto, err := dtos.NewListPrototypesDTO(query)
+	if err == nil {
+		err = dto.Validate()
+	}
+	if err != nil {
+		entry.Error(err.Error())
+		respondUnprocessable(ctx, cc, err, "dto.validate.ListPrototypesDTO")
+		return
+	}
+
+	response := c.prototypesService.Export(cc, dto.ToCommand())
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	mappings, warnings := dtos.NewWireMockMappingsDTO(response.Results)
+	for _, warning := range warnings {
+		entry.Warnf("WireMock export: %s", warning)
+	}
+
+	document, err := json.MarshalIndent(mappings, "", "  ")
+	if err != nil {
+		entry.Error(err.Error())
+		failed := utils.Response[any]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "prototypes.wiremock.encode")),
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+		ctx.JSON(failed.StatusCode, failed.ToMapWithCustomContext(cc))
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="mappings.json"`)
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", document)
+}
@@ -0,0 +1,160 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BundleVersion es la versión del formato de import/export
+const BundleVersion = 1
+
+// Formatos del bundle
+const (
+	BundleFormatJSON = "json"
+	BundleFormatYAML = "yaml"
+)
+
+// BundlePrototypeDTO es un prototype sin id ni fechas, para moverlo entre ambientes
+type BundlePrototypeDTO struct {
+	Name     string                  `json:"name"`
+	Group    string                  `json:"group,omitempty"`
+	Request  entities.RequestEntity  `json:"request"`
+	Response entities.ResponseEntity `json:"response"`
+
+	Overrides []string `json:"overrides,omitempty"`
+}
+
+// ExportBundleDTO es el archivo que produce GET /v1/prototypes/export
+type ExportBundleDTO struct {
+	Version    int                  `json:"version"`
+	ExportedAt time.Time            `json:"exported_at"`
+	Prototypes []BundlePrototypeDTO `json:"prototypes"`
+}
+
+func NewExportBundleDTO(models []prototypes.PrototypeModel, exportedAt time.Time) ExportBundleDTO {
+	bundle := ExportBundleDTO{
+		Version:    BundleVersion,
+		ExportedAt: exportedAt.UTC(),
+		Prototypes: make([]BundlePrototypeDTO, 0, len(models)),
+	}
+	for _, model := range models {
+		bundle.Prototypes = append(bundle.Prototypes, BundlePrototypeDTO{
+			Name:     model.Name,
+			Group:    model.Group,
+			Request:  model.Request,
+			Response: model.Response,
+
+			Overrides: model.Overrides,
+		})
+	}
+	return bundle
+}
+
+// Encode serializa el bundle; el YAML usa las mismas llaves que el JSON
+func (bundle ExportBundleDTO) Encode(format string) ([]byte, error) {
+	raw, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil || format != BundleFormatYAML {
+		return raw, err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// ImportPrototypesDTO es el bundle que recibe POST /v1/prototypes/import (mismo formato que el export)
+type ImportPrototypesDTO struct {
+	Version    int                  `json:"version"`
+	Prototypes []CreatePrototypeDTO `json:"prototypes"`
+}
+
+// DecodeImportPrototypesDTO lee un bundle JSON o YAML
+func DecodeImportPrototypesDTO(body []byte, format string) (ImportPrototypesDTO, error) {
+	var dto ImportPrototypesDTO
+
+	if format == BundleFormatYAML {
+		raw, err := yamlToJSON(body)
+		if err != nil {
+			return dto, errors.New("invalid YAML bundle: " + err.Error())
+		}
+		body = raw
+	}
+
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return dto, errors.New("invalid JSON bundle: " + err.Error())
+	}
+	return dto, nil
+}
+
+// BundleFormat elige el formato a partir de ?format= o del Content-Type / Accept
+func BundleFormat(explicit, mediaType string) string {
+	switch strings.ToLower(explicit) {
+	case BundleFormatYAML, "yml":
+		return BundleFormatYAML
+	case BundleFormatJSON:
+		return BundleFormatJSON
+	}
+	if strings.Contains(strings.ToLower(mediaType), "yaml") {
+		return BundleFormatYAML
+	}
+	return BundleFormatJSON
+}
+
+func (dto ImportPrototypesDTO) Validate() error {
+
+	if dto.Version != 0 && dto.Version != BundleVersion {
+		return fmt.Errorf("unsupported bundle version %d (supported: %d)", dto.Version, BundleVersion)
+	}
+
+	if len(dto.Prototypes) == 0 {
+		return errors.New("prototypes is required")
+	}
+
+	seen := map[string]int{}
+	for i, prototype := range dto.Prototypes {
+		if err := prototype.Validate(); err != nil {
+			return fmt.Errorf("prototypes[%d] (%s): %s", i, prototype.Name, err.Error())
+		}
+		key := strings.ToUpper(prototype.Request.Method) + " " + prototype.Request.UrlPath
+		if first, duplicated := seen[key]; duplicated {
+			return fmt.Errorf("prototypes[%d] (%s): %s is already declared by prototypes[%d]", i, prototype.Name, key, first)
+		}
+		seen[key] = i
+	}
+
+	return nil
+}
+
+func (dto ImportPrototypesDTO) ToCommand() commands.ImportPrototypesCommand {
+	command := commands.ImportPrototypesCommand{
+		Prototypes: make([]commands.CreatePrototypeCommand, 0, len(dto.Prototypes)),
+	}
+	for _, prototype := range dto.Prototypes {
+		command.Prototypes = append(command.Prototypes, prototype.ToCommand())
+	}
+	return command
+}
+
+// importWarnings junta, sin repetir, lo que un import desde otro formato no pudo traducir
+type importWarnings struct {
+	warnings []string
+}
+
+func (w *importWarnings) warn(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	for _, existing := range w.warnings {
+		if existing == warning {
+			return
+		}
+	}
+	w.warnings = append(w.warnings, warning)
+}
@@ -0,0 +1,239 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Segmentos de un path capturado que se vuelven path params, con la regex que los valida
+var capturedSegmentParams = []struct {
+	re    *regexp.Regexp
+	regex string
+}{
+	{regexp.MustCompile(`^\d+$`), `\d+`},
+	{regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`), `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`},
+	{regexp.MustCompile(`^[0-9a-fA-F]{24}$`), `[0-9a-fA-F]{24}`}, // ObjectId
+}
+
+// variable de Postman usada como segmento: /users/{{userId}}
+var postmanVariableSegmentRe = regexp.MustCompile(`^\{\{([A-Za-z_][A-Za-z0-9_]*)\}\}$`)
+
+var resourceSegmentRe = regexp.MustCompile(`^[A-Za-z][A-Za-z_-]*$`)
+
+// headers de transporte o de sesión que no tiene sentido repetir en un mock
+var capturedIgnoredHeaders = map[string]bool{
+	"content-length": true, "content-encoding": true, "transfer-encoding": true, "connection": true,
+	"keep-alive": true, "date": true, "server": true, "set-cookie": true, "vary": true, "etag": true,
+	"last-modified": true, "age": true, "expires": true, "pragma": true, "cache-control": true,
+	"strict-transport-security": true, "alt-svc": true, "via": true, "x-powered-by": true,
+	"report-to": true, "nel": true, "content-security-policy": true,
+}
+
+// capturedExchange es un par request/response de una colección de Postman o de una captura HAR
+type capturedExchange struct {
+	where  string
+	name   string
+	group  string
+	method string
+	path   string // sin host ni query; Postman puede traer :id o {{var}}
+
+	status  int
+	headers map[string]string
+	body    string
+}
+
+// captureConverter arma los prototypes de una lista de exchanges: los paths concretos se
+// vuelven templates (/users/42 -> /users/:userId) y de los exchanges que caen en la misma
+// ruta queda uno solo, el primero con respuesta 2xx.
+type captureConverter struct {
+	importWarnings
+}
+
+func (c *captureConverter) prototypes(exchanges []capturedExchange) []CreatePrototypeDTO {
+
+	type route struct {
+		prototype CreatePrototypeDTO
+		where     string
+		status    int
+		count     int
+	}
+
+	var order []string
+	routes := map[string]*route{}
+
+	for _, exchange := range exchanges {
+		urlPath, pathParams := templatePath(exchange.path)
+		key := exchange.method + " " + routeShape(urlPath)
+
+		current, seen := routes[key]
+		if !seen {
+			current = &route{}
+			routes[key] = current
+			order = append(order, key)
+		}
+		current.count++
+		if seen && (successStatus(current.status) || !successStatus(exchange.status)) {
+			continue
+		}
+
+		name := exchange.name
+		if name == "" {
+			name = exchange.method + " " + urlPath
+		}
+		current.prototype = CreatePrototypeDTO{
+			Name:  name,
+			Group: exchange.group,
+			Request: RequestDTO{
+				Method:     exchange.method,
+				UrlPath:    urlPath,
+				PathParams: pathParams,
+			},
+			Response: c.response(exchange),
+		}
+		current.where = exchange.where
+		current.status = exchange.status
+	}
+
+	prototypes := make([]CreatePrototypeDTO, 0, len(order))
+	for _, key := range order {
+		current := routes[key]
+		if current.count > 1 {
+			c.warn("%s %s: %d entries hit this route, kept the response of %s", current.prototype.Request.Method, current.prototype.Request.UrlPath, current.count, current.where)
+		}
+		prototypes = append(prototypes, current.prototype)
+	}
+	return prototypes
+}
+
+// response copia status, headers útiles y body (JSON si se puede decodificar; si no, texto)
+func (c *captureConverter) response(exchange capturedExchange) ResponseDTO {
+	dto := ResponseDTO{StatusCode: exchange.status}
+
+	contentType := ""
+	for name, value := range exchange.headers {
+		lower := strings.ToLower(name)
+		switch {
+		case lower == "content-type":
+			contentType = value
+		case capturedIgnoredHeaders[lower], strings.HasPrefix(lower, "access-control-"), strings.HasPrefix(lower, ":"):
+		default:
+			if dto.Headers == nil {
+				dto.Headers = map[string]string{}
+			}
+			dto.Headers[name] = value
+		}
+	}
+
+	if strings.TrimSpace(exchange.body) != "" {
+		var decoded any
+		if jsonMediaType(contentType) && json.Unmarshal([]byte(exchange.body), &decoded) == nil {
+			dto.Body = decoded
+		} else {
+			dto.Body = exchange.body
+			if contentType == "" {
+				contentType = "text/plain"
+			}
+		}
+	}
+
+	if contentType != "" && !strings.Contains(strings.ToLower(contentType), "json") {
+		if dto.Headers == nil {
+			dto.Headers = map[string]string{}
+		}
+		dto.Headers["Content-Type"] = contentType
+	}
+	return dto
+}
+
+// jsonMediaType: sin Content-Type se intenta JSON, como hace el mock al responder
+func jsonMediaType(contentType string) bool {
+	return contentType == "" || strings.Contains(strings.ToLower(contentType), "json")
+}
+
+func successStatus(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// templatePath cambia los segmentos con ids (números, UUIDs, ObjectIds) y las variables de
+// Postman por path params con el nombre del recurso anterior: /users/42 -> /users/:userId
+func templatePath(path string) (string, map[string]string) {
+	var params map[string]string
+	used := map[string]int{}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		name, regex := "", ""
+
+		// {{var}} antes que {param}: pathSegmentParam también aceptaría las llaves dobles
+		if match := postmanVariableSegmentRe.FindStringSubmatch(segment); match != nil {
+			name = match[1]
+		} else if param, ok := pathSegmentParam(segment); ok {
+			used[param]++
+			continue
+		} else {
+			for _, candidate := range capturedSegmentParams {
+				if candidate.re.MatchString(segment) {
+					regex = candidate.regex
+					break
+				}
+			}
+			if regex == "" {
+				continue
+			}
+			name = "id"
+			if i > 0 && resourceSegmentRe.MatchString(segments[i-1]) {
+				name = singular(segments[i-1]) + "Id"
+			}
+		}
+
+		used[name]++
+		if used[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, used[name])
+		}
+		segments[i] = ":" + name
+		if regex != "" {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[name] = matcherRegex(regex)
+		}
+	}
+	return "/" + strings.Join(segments, "/"), params
+}
+
+// routeShape borra los nombres de los path params: /users/:id y /users/:userId son la misma ruta
+func routeShape(urlPath string) string {
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		if _, ok := pathSegmentParam(segment); ok {
+			segments[i] = ":"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// singular de un segmento de recurso, en lowerCamelCase: "user-groups" -> "userGroup"
+func singular(resource string) string {
+	parts := strings.FieldsFunc(resource, func(r rune) bool { return r == '-' || r == '_' })
+	for i, part := range parts {
+		part = strings.ToLower(part)
+		if i > 0 {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		parts[i] = part
+	}
+	word := strings.Join(parts, "")
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses") || strings.HasSuffix(word, "xes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
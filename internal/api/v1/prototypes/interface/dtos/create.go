@@ -5,12 +5,18 @@ import (
 	"errors"
 	"mocky/internal/api/v1/prototypes/domain/commands"
 	"mocky/internal/api/v1/prototypes/domain/entities"
+	"mocky/internal/context/controllers/jsonschema"
+	"strings"
 )
 
 type CreatePrototypeDTO struct {
 	Request  RequestDTO  `json:"request" binding:"required"`
 	Response ResponseDTO `json:"response" binding:"required"`
 	Name     string      `json:"name"`
+	Group    string      `json:"group"`
+
+	// Overrides marca rutas editadas a mano que un re-import no debe pisar
+	Overrides []string `json:"overrides"`
 }
 
 func (dto CreatePrototypeDTO) Validate() error {
@@ -19,15 +25,29 @@ func (dto CreatePrototypeDTO) Validate() error {
 		return errors.New("request is invalid: " + dto.Request.Validate().Error())
 	}
 
+	if dto.Response.Validate() != nil {
+		return errors.New("response is invalid: " + dto.Response.Validate().Error())
+	}
+
+	if err := validateOverrides(dto.Overrides); err != nil {
+		return errors.New("overrides is invalid: " + err.Error())
+	}
+
 	return nil
 }
 
 type RequestDTO struct {
-	Method     string            `json:"method" binding:"required"`
-	UrlPath    string            `json:"urlPath" binding:"required"`
-	Headers    map[string]string `json:"headers"`
-	PathParams map[string]string `json:"path_params"`
-	BodySchema *BodySchemaDTO    `json:"bodySchema"`
+	Method         string                      `json:"method" binding:"required"`
+	UrlPath        string                      `json:"urlPath" binding:"required"`
+	Headers        map[string]string           `json:"headers"`
+	HeaderMatchers map[string]ValuesMatcherDTO `json:"header_matchers"`
+	QueryMatchers  map[string]ValuesMatcherDTO `json:"query_matchers"`
+	PathParams     map[string]string           `json:"path_params"`
+	QuerySchema    []PropertyDTO               `json:"query_schema"`
+	HeaderSchema   []PropertyDTO               `json:"header_schema"`
+	BodySchema     *BodySchemaDTO              `json:"bodySchema"`
+	JSONSchema     map[string]any              `json:"jsonSchema"`
+	ErrorFormat    string                      `json:"error_format"`
 
 	Delay int `json:"delay"`
 }
@@ -46,6 +66,38 @@ func (dto RequestDTO) Validate() error {
 		return errors.New("bodySchema is invalid: " + dto.BodySchema.Validate().Error())
 	}
 
+	if dto.JSONSchema != nil {
+		if _, err := jsonschema.Compile(dto.JSONSchema); err != nil {
+			return errors.New("jsonSchema is invalid: " + err.Error())
+		}
+	}
+
+	if err := validateParamsSchema(dto.QuerySchema); err != nil {
+		return errors.New("query_schema is invalid: " + err.Error())
+	}
+
+	if err := validateParamsSchema(dto.HeaderSchema); err != nil {
+		return errors.New("header_schema is invalid: " + err.Error())
+	}
+
+	switch dto.ErrorFormat {
+	case "", entities.ErrorFormatDefault, entities.ErrorFormatSimple, entities.ErrorFormatProblem:
+	default:
+		return errors.New("error_format must be 'default', 'simple' or 'problem'")
+	}
+
+	for name, matcher := range dto.HeaderMatchers {
+		if matcher.Validate() != nil {
+			return errors.New("header_matchers." + name + " is invalid: " + matcher.Validate().Error())
+		}
+	}
+
+	for name, matcher := range dto.QueryMatchers {
+		if matcher.Validate() != nil {
+			return errors.New("query_matchers." + name + " is invalid: " + matcher.Validate().Error())
+		}
+	}
+
 	return nil
 }
 
@@ -57,13 +109,102 @@ func (dto RequestDTO) ToEntity() entities.RequestEntity {
 	}
 
 	return entities.RequestEntity{
-		Method:     dto.Method,
-		UrlPath:    dto.UrlPath,
-		Headers:    dto.Headers,
-		PathParams: dto.PathParams,
-		BodySchema: &bodySchema,
-		Delay:      dto.Delay,
+		Method:         dto.Method,
+		UrlPath:        dto.UrlPath,
+		Headers:        dto.Headers,
+		HeaderMatchers: valuesMatchersToEntity(dto.HeaderMatchers),
+		QueryMatchers:  valuesMatchersToEntity(dto.QueryMatchers),
+		PathParams:     dto.PathParams,
+		QuerySchema:    branchesToEntity(dto.QuerySchema),
+		HeaderSchema:   branchesToEntity(dto.HeaderSchema),
+		BodySchema:     &bodySchema,
+		JSONSchema:     dto.JSONSchema,
+		ErrorFormat:    dto.ErrorFormat,
+		Delay:          dto.Delay,
+	}
+}
+
+// validateParamsSchema: query params y headers llegan como texto, solo admiten escalares y arrays de escalares
+func validateParamsSchema(properties []PropertyDTO) error {
+
+	scalar := func(typ string) bool {
+		switch strings.ToLower(typ) {
+		case "string", "integer", "number", "boolean":
+			return true
+		}
+		return false
+	}
+
+	for _, property := range properties {
+		if property.Validate() != nil {
+			return errors.New(property.Validate().Error())
+		}
+		if strings.ToLower(property.Type) == "array" {
+			if property.Items != nil && !scalar(property.Items.Type) {
+				return errors.New(property.Name + " items must be string, integer, number or boolean")
+			}
+			continue
+		}
+		if !scalar(property.Type) {
+			return errors.New(property.Name + " type must be string, integer, number, boolean or array")
+		}
+	}
+
+	return nil
+}
+
+type ValuesMatcherDTO struct {
+	Pattern  string `json:"pattern"`
+	Match    string `json:"match"`
+	Count    *int   `json:"count"`
+	MinCount *int   `json:"min_count"`
+	MaxCount *int   `json:"max_count"`
+}
+
+func (dto ValuesMatcherDTO) Validate() error {
+
+	if dto.Match != "" && dto.Match != entities.MatchAnyValue && dto.Match != entities.MatchAllValues {
+		return errors.New("match must be 'any' or 'all'")
+	}
+
+	for _, count := range []*int{dto.Count, dto.MinCount, dto.MaxCount} {
+		if count != nil && *count < 0 {
+			return errors.New("counts must be greater or equal than 0")
+		}
 	}
+
+	if dto.MinCount != nil && dto.MaxCount != nil && *dto.MinCount > *dto.MaxCount {
+		return errors.New("min_count must be less or equal than max_count")
+	}
+
+	return nil
+}
+
+func (dto ValuesMatcherDTO) ToEntity() entities.ValuesMatcherEntity {
+	return entities.ValuesMatcherEntity{
+		Pattern:  dto.Pattern,
+		Match:    dto.Match,
+		Count:    dto.Count,
+		MinCount: dto.MinCount,
+		MaxCount: dto.MaxCount,
+	}
+}
+
+// matcherRegex expresa una regex completa con la convención de headers y matchers: el "^"
+// inicial solo marca el valor como regex (no se compila), así que se antepone otro para anclarla
+func matcherRegex(expr string) string {
+	return "^^(?:" + expr + ")$"
+}
+
+func valuesMatchersToEntity(matchers map[string]ValuesMatcherDTO) map[string]entities.ValuesMatcherEntity {
+	if matchers == nil {
+		return nil
+	}
+	out := make(map[string]entities.ValuesMatcherEntity, len(matchers))
+	for name, matcher := range matchers {
+		out[name] = matcher.ToEntity()
+	}
+	return out
 }
 
 type BodySchemaDTO struct {
@@ -71,6 +212,17 @@ type BodySchemaDTO struct {
 	TypeSchema          string        `json:"type_schema" binding:"required"`
 	AditionalProperties bool          `json:"aditional_properties"`
 	Properties          []PropertyDTO `json:"properties"`
+
+	// Solo para type_schema "array"
+	Items       *PropertyDTO `json:"items"`
+	MinItems    int32        `json:"min_items"`
+	MaxItems    int32        `json:"max_items"`
+	UniqueItems bool         `json:"unique_items"`
+
+	OneOf         []PropertyDTO     `json:"one_of"`
+	AnyOf         []PropertyDTO     `json:"any_of"`
+	AllOf         []PropertyDTO     `json:"all_of"`
+	Discriminator *DiscriminatorDTO `json:"discriminator"`
 }
 
 func (dto BodySchemaDTO) Validate() error {
@@ -83,12 +235,24 @@ func (dto BodySchemaDTO) Validate() error {
 		return errors.New("typeSchema is required")
 	}
 
+	if typeSchema := strings.ToLower(dto.TypeSchema); typeSchema != "object" && typeSchema != "array" {
+		return errors.New("type_schema must be 'object' or 'array'")
+	}
+
 	for _, property := range dto.Properties {
 		if property.Validate() != nil {
 			return errors.New("properties is invalid: " + property.Validate().Error())
 		}
 	}
 
+	if err := validateArrayRules(dto.Items, dto.MinItems, dto.MaxItems); err != nil {
+		return err
+	}
+
+	if err := validateComposition(dto.OneOf, dto.AnyOf, dto.AllOf, dto.Discriminator); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -101,11 +265,20 @@ func (dto BodySchemaDTO) ToEntity() entities.BodySchemaEntity {
 		Properties: ctypes.Map(dto.Properties, func(property PropertyDTO) entities.PropertyEntity {
 			return property.ToEntity()
 		}),
+		Items:       itemsToEntity(dto.Items),
+		MinItems:    dto.MinItems,
+		MaxItems:    dto.MaxItems,
+		UniqueItems: dto.UniqueItems,
+
+		OneOf:         branchesToEntity(dto.OneOf),
+		AnyOf:         branchesToEntity(dto.AnyOf),
+		AllOf:         branchesToEntity(dto.AllOf),
+		Discriminator: dto.Discriminator.ToEntity(),
 	}
 }
 
 type PropertyDTO struct {
-	Name       string        `json:"name" binding:"required"`
+	Name       string        `json:"name"` // los items de un array no llevan nombre; Validate lo exige en properties
 	IsRequired bool          `json:"is_required"`
 	Type       string        `json:"type" binding:"required"`
 	MinLength  int32         `json:"min_length"`
@@ -113,6 +286,48 @@ type PropertyDTO struct {
 	Format     string        `json:"format"`
 	Pattern    string        `json:"pattern"`
 	Properties []PropertyDTO `json:"properties"`
+
+	// type "array"
+	Items       *PropertyDTO `json:"items"`
+	MinItems    int32        `json:"min_items"`
+	MaxItems    int32        `json:"max_items"`
+	UniqueItems bool         `json:"unique_items"`
+
+	// type "number" / "integer"
+	Minimum          *float64 `json:"minimum"`
+	Maximum          *float64 `json:"maximum"`
+	ExclusiveMinimum *float64 `json:"exclusive_minimum"`
+	ExclusiveMaximum *float64 `json:"exclusive_maximum"`
+	MultipleOf       *float64 `json:"multiple_of"`
+
+	Enum     []any `json:"enum"`
+	Const    any   `json:"const"`
+	Nullable bool  `json:"nullable"`
+	Default  any   `json:"default"`
+
+	// type "object": sin valor hereda el del objeto padre
+	AdditionalProperties *bool `json:"additional_properties"`
+
+	// Las ramas no requieren nombre, salvo para un discriminator sin mapping
+	OneOf         []PropertyDTO     `json:"one_of"`
+	AnyOf         []PropertyDTO     `json:"any_of"`
+	AllOf         []PropertyDTO     `json:"all_of"`
+	Discriminator *DiscriminatorDTO `json:"discriminator"`
+}
+
+type DiscriminatorDTO struct {
+	PropertyName string            `json:"property_name"`
+	Mapping      map[string]string `json:"mapping"`
+}
+
+func (dto *DiscriminatorDTO) ToEntity() *entities.DiscriminatorEntity {
+	if dto == nil {
+		return nil
+	}
+	return &entities.DiscriminatorEntity{
+		PropertyName: dto.PropertyName,
+		Mapping:      dto.Mapping,
+	}
 }
 
 func (dto PropertyDTO) Validate() error {
@@ -121,13 +336,140 @@ func (dto PropertyDTO) Validate() error {
 		return errors.New("name is required")
 	}
 
+	return dto.validateRules()
+}
+
+// validateRules valida todo menos el nombre, que los items de un array no llevan
+func (dto PropertyDTO) validateRules() error {
+
 	if dto.Type == "" {
 		return errors.New("type is required")
 	}
 
+	for _, property := range dto.Properties {
+		if property.Validate() != nil {
+			return errors.New(dto.Name + ".properties is invalid: " + property.Validate().Error())
+		}
+	}
+
+	if err := validateArrayRules(dto.Items, dto.MinItems, dto.MaxItems); err != nil {
+		return errors.New(dto.Name + " " + err.Error())
+	}
+
+	if err := dto.validateNumericRules(); err != nil {
+		return errors.New(dto.Name + " " + err.Error())
+	}
+
+	if dto.Enum != nil && len(dto.Enum) == 0 {
+		return errors.New(dto.Name + " enum must have at least one value")
+	}
+
+	if err := validateComposition(dto.OneOf, dto.AnyOf, dto.AllOf, dto.Discriminator); err != nil {
+		return errors.New(dto.Name + " " + err.Error())
+	}
+
 	return nil
 }
 
+func validateComposition(oneOf, anyOf, allOf []PropertyDTO, discriminator *DiscriminatorDTO) error {
+
+	keywords := []string{"one_of", "any_of", "all_of"}
+	for i, branches := range [][]PropertyDTO{oneOf, anyOf, allOf} {
+		for _, branch := range branches {
+			if branch.validateRules() != nil {
+				return errors.New(keywords[i] + " is invalid: " + branch.validateRules().Error())
+			}
+		}
+	}
+
+	if discriminator == nil {
+		return nil
+	}
+
+	if discriminator.PropertyName == "" {
+		return errors.New("discriminator.property_name is required")
+	}
+
+	candidates := append(append([]PropertyDTO{}, oneOf...), anyOf...)
+	if len(candidates) == 0 {
+		return errors.New("discriminator requires one_of or any_of")
+	}
+
+	names := make(map[string]bool, len(candidates))
+	for _, branch := range candidates {
+		if branch.Name == "" && len(discriminator.Mapping) == 0 {
+			return errors.New("discriminator without mapping requires a name in every branch")
+		}
+		if branch.Name != "" && names[branch.Name] {
+			return errors.New("discriminator branch names must be unique: " + branch.Name)
+		}
+		names[branch.Name] = true
+	}
+
+	for value, name := range discriminator.Mapping {
+		if !names[name] {
+			return errors.New("discriminator.mapping." + value + " points to unknown branch " + name)
+		}
+	}
+
+	return nil
+}
+
+func branchesToEntity(branches []PropertyDTO) []entities.PropertyEntity {
+	if len(branches) == 0 {
+		return nil
+	}
+	return ctypes.Map(branches, func(branch PropertyDTO) entities.PropertyEntity {
+		return branch.ToEntity()
+	})
+}
+
+func (dto PropertyDTO) validateNumericRules() error {
+
+	if dto.MultipleOf != nil && *dto.MultipleOf <= 0 {
+		return errors.New("multiple_of must be greater than 0")
+	}
+
+	lower := dto.Minimum
+	if dto.ExclusiveMinimum != nil && (lower == nil || *dto.ExclusiveMinimum > *lower) {
+		lower = dto.ExclusiveMinimum
+	}
+	upper := dto.Maximum
+	if dto.ExclusiveMaximum != nil && (upper == nil || *dto.ExclusiveMaximum < *upper) {
+		upper = dto.ExclusiveMaximum
+	}
+	if lower != nil && upper != nil && *lower > *upper {
+		return errors.New("minimum must be less or equal than maximum")
+	}
+
+	return nil
+}
+
+func validateArrayRules(items *PropertyDTO, minItems int32, maxItems int32) error {
+
+	if minItems < 0 || maxItems < 0 {
+		return errors.New("min_items and max_items must be greater or equal than 0")
+	}
+
+	if maxItems > 0 && minItems > maxItems {
+		return errors.New("min_items must be less or equal than max_items")
+	}
+
+	if items != nil && items.validateRules() != nil {
+		return errors.New("items is invalid: " + items.validateRules().Error())
+	}
+
+	return nil
+}
+
+func itemsToEntity(items *PropertyDTO) *entities.PropertyEntity {
+	if items == nil {
+		return nil
+	}
+	entity := items.ToEntity()
+	return &entity
+}
+
 func (dto PropertyDTO) ToEntity() entities.PropertyEntity {
 
 	return entities.PropertyEntity{
@@ -141,6 +483,27 @@ func (dto PropertyDTO) ToEntity() entities.PropertyEntity {
 		Properties: ctypes.Map(dto.Properties, func(property PropertyDTO) entities.PropertyEntity {
 			return property.ToEntity()
 		}),
+		Items:       itemsToEntity(dto.Items),
+		MinItems:    dto.MinItems,
+		MaxItems:    dto.MaxItems,
+		UniqueItems: dto.UniqueItems,
+
+		Minimum:          dto.Minimum,
+		Maximum:          dto.Maximum,
+		ExclusiveMinimum: dto.ExclusiveMinimum,
+		ExclusiveMaximum: dto.ExclusiveMaximum,
+		MultipleOf:       dto.MultipleOf,
+
+		Enum:     dto.Enum,
+		Const:    dto.Const,
+		Nullable: dto.Nullable,
+		Default:  dto.Default,
+
+		AdditionalProperties: dto.AdditionalProperties,
+		OneOf:                branchesToEntity(dto.OneOf),
+		AnyOf:                branchesToEntity(dto.AnyOf),
+		AllOf:                branchesToEntity(dto.AllOf),
+		Discriminator:        dto.Discriminator.ToEntity(),
 	}
 }
 
@@ -149,20 +512,64 @@ func (dto CreatePrototypeDTO) ToCommand() commands.CreatePrototypeCommand {
 		Request:  dto.Request.ToEntity(),
 		Response: dto.Response.ToEntity(),
 		Name:     dto.Name,
+		Group:    dto.Group,
+
+		Overrides: dto.Overrides,
 	}
 }
 
 type ResponseDTO struct {
-	Body map[string]any `json:"body"`
+	StatusCode   int               `json:"statusCode"`
+	Headers      map[string]string `json:"headers"`
+	Body         any               `json:"body"`
+	Schema       *BodySchemaDTO    `json:"schema"`
+	JSONSchema   map[string]any    `json:"jsonSchema"`
+	ContractMode string            `json:"contract_mode"`
 }
 
 func (dto ResponseDTO) Validate() error {
 
+	if dto.StatusCode != 0 && (dto.StatusCode < 100 || dto.StatusCode > 599) {
+		return errors.New("statusCode must be between 100 and 599")
+	}
+
+	if dto.Schema != nil && dto.Schema.Validate() != nil {
+		return errors.New("schema is invalid: " + dto.Schema.Validate().Error())
+	}
+
+	if dto.JSONSchema != nil {
+		if _, err := jsonschema.Compile(dto.JSONSchema); err != nil {
+			return errors.New("jsonSchema is invalid: " + err.Error())
+		}
+	}
+
+	switch dto.ContractMode {
+	case "", entities.ContractModeWarn, entities.ContractModeStrict:
+	default:
+		return errors.New("contract_mode must be 'warn' or 'strict'")
+	}
+
+	if dto.ContractMode != "" && dto.Schema == nil && dto.JSONSchema == nil {
+		return errors.New("contract_mode requires schema or jsonSchema")
+	}
+
 	return nil
 }
 
 func (dto ResponseDTO) ToEntity() entities.ResponseEntity {
+
+	var schema *entities.BodySchemaEntity
+	if dto.Schema != nil {
+		entity := dto.Schema.ToEntity()
+		schema = &entity
+	}
+
 	return entities.ResponseEntity{
-		Body: dto.Body,
+		StatusCode:   dto.StatusCode,
+		Headers:      dto.Headers,
+		Body:         dto.Body,
+		Schema:       schema,
+		JSONSchema:   dto.JSONSchema,
+		ContractMode: dto.ContractMode,
 	}
 }
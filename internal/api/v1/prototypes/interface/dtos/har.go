@@ -0,0 +1,153 @@
+package dtos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HAR 1.1 y 1.2 comparten la forma de las entries
+var harVersions = map[string]bool{"1.1": true, "1.2": true}
+
+// tipos de recurso de DevTools que son llamadas a APIs
+var harAPIResourceTypes = map[string]bool{"xhr": true, "fetch": true}
+
+// HARDocumentDTO es la captura (HAR 1.2) que recibe POST /v1/prototypes/import/har
+type HARDocumentDTO struct {
+	Log HARLogDTO `json:"log"`
+}
+
+type HARLogDTO struct {
+	Version string        `json:"version"`
+	Entries []HAREntryDTO `json:"entries"`
+}
+
+type HAREntryDTO struct {
+	Request  HARRequestDTO  `json:"request"`
+	Response HARResponseDTO `json:"response"`
+
+	// extensión de Chrome/Firefox: document, script, xhr, fetch, ...
+	ResourceType string `json:"_resourceType"`
+}
+
+type HARRequestDTO struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type HARResponseDTO struct {
+	Status  int            `json:"status"`
+	Headers []HARHeaderDTO `json:"headers"`
+	Content HARContentDTO  `json:"content"`
+}
+
+type HARHeaderDTO struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARContentDTO struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
+
+// DecodeHARDocumentDTO lee una captura HAR (JSON)
+func DecodeHARDocumentDTO(body []byte) (HARDocumentDTO, error) {
+	var dto HARDocumentDTO
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return dto, errors.New("invalid HAR document: " + err.Error())
+	}
+	return dto, nil
+}
+
+func (dto HARDocumentDTO) Validate() error {
+
+	if !harVersions[dto.Log.Version] {
+		return fmt.Errorf("unsupported HAR version %q (supported: 1.2)", dto.Log.Version)
+	}
+
+	if len(dto.Log.Entries) == 0 {
+		return errors.New("log.entries is required")
+	}
+
+	return nil
+}
+
+// ToImportPrototypesDTO genera un prototype por llamada a API de la captura, agrupado por
+// host (o en group). Se omiten los recursos estáticos, los preflight (OPTIONS) y las
+// requests sin respuesta.
+func (dto HARDocumentDTO) ToImportPrototypesDTO(group string) (ImportPrototypesDTO, []string) {
+
+	converter := &captureConverter{}
+
+	var exchanges []capturedExchange
+	skipped := 0
+	for i, entry := range dto.Log.Entries {
+		where := fmt.Sprintf("entries[%d]", i)
+
+		if !entry.isAPICall() {
+			skipped++
+			continue
+		}
+
+		parsed, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			converter.warn("%s: url %q is invalid, entry skipped", where, entry.Request.URL)
+			continue
+		}
+
+		body := entry.Response.Content.Text
+		if entry.Response.Content.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(body)
+			if err != nil {
+				converter.warn("%s: response body is not valid base64, body left empty", where)
+				decoded = nil
+			}
+			body = string(decoded)
+		}
+
+		headers := make(map[string]string, len(entry.Response.Headers)+1)
+		for _, header := range entry.Response.Headers {
+			headers[header.Name] = header.Value
+		}
+		if headerValue(headers, "Content-Type") == "" && entry.Response.Content.MimeType != "" {
+			headers["Content-Type"] = entry.Response.Content.MimeType
+		}
+
+		entryGroup := group
+		if entryGroup == "" {
+			entryGroup = parsed.Host
+		}
+
+		exchanges = append(exchanges, capturedExchange{
+			where:   fmt.Sprintf("%s (%s %s)", where, strings.ToUpper(entry.Request.Method), parsed.Path),
+			group:   entryGroup,
+			method:  strings.ToUpper(entry.Request.Method),
+			path:    parsed.Path,
+			status:  entry.Response.Status,
+			headers: headers,
+			body:    body,
+		})
+	}
+	if skipped > 0 {
+		converter.warn("%d entries skipped (static resources, CORS preflights or requests without response)", skipped)
+	}
+
+	return ImportPrototypesDTO{Version: BundleVersion, Prototypes: converter.prototypes(exchanges)}, converter.warnings
+}
+
+// isAPICall: xhr/fetch según DevTools o, si la captura no lo indica, una respuesta JSON
+func (entry HAREntryDTO) isAPICall() bool {
+	if entry.Response.Status == 0 || strings.EqualFold(entry.Request.Method, http.MethodOptions) {
+		return false
+	}
+	if entry.ResourceType != "" {
+		return harAPIResourceTypes[entry.ResourceType]
+	}
+	return strings.Contains(strings.ToLower(entry.Response.Content.MimeType), "json")
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "testing"
+
+func TestImportHAR(t *testing.T) {
+	dto, err := DecodeHARDocumentDTO(readImportFixture(t, "har"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dto.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bundle, warnings := dto.ToImportPrototypesDTO("")
+	checkImportFixture(t, "har", bundle, warnings)
+}
+
+func TestImportHARGroup(t *testing.T) {
+	dto, err := DecodeHARDocumentDTO(readImportFixture(t, "har"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, _ := dto.ToImportPrototypesDTO("captured")
+	for _, prototype := range bundle.Prototypes {
+		if prototype.Group != "captured" {
+			t.Fatalf("%s: group = %q, want captured", prototype.Name, prototype.Group)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package dtos
+
+import (
+	"common/domain/criteria"
+	"errors"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+// campos del listado -> campos del repositorio
+var listFields = map[string]criteria.FilterField{
+	"id":        "_id",
+	"name":      "name",
+	"group":     "group",
+	"method":    "request.method",
+	"path":      "request.urlPath",
+	"createdAt": "createdAt",
+	"updatedAt": "updatedAt",
+}
+
+// campos de fecha: aceptan RFC 3339 o YYYY-MM-DD y no admiten like
+var listDateFields = map[string]bool{"createdAt": true, "updatedAt": true}
+
+// operadores en forma campo[op]=valor
+var listOperators = map[string]criteria.Operator{
+	"eq":    criteria.OperatorEqual,
+	"ne":    criteria.OperatorNotEqual,
+	"gt":    criteria.OperatorGreaterThan,
+	"gte":   criteria.OperatorGreaterEqual,
+	"lt":    criteria.OperatorLessThan,
+	"lte":   criteria.OperatorLessEqual,
+	"like":  criteria.OperatorLike,
+	"nlike": criteria.OperatorNotLike,
+	"in":    criteria.OperatorIn,
+	"nin":   criteria.OperatorNotIn,
+}
+
+// atajos en forma campo<sufijo>=valor (path~=/v1/users, method!=GET, createdAt>=2024-01-01)
+var listSuffixes = []struct {
+	suffix   string
+	operator criteria.Operator
+}{
+	{"!~", criteria.OperatorNotLike},
+	{"~", criteria.OperatorLike},
+	{"!", criteria.OperatorNotEqual},
+	{">", criteria.OperatorGreaterEqual},
+	{"<", criteria.OperatorLessEqual},
+}
+
+type ListFilterDTO struct {
+	Field    string
+	Operator criteria.Operator
+	Value    string
+}
+
+// ListPrototypesDTO se arma desde el query string de GET /v1/prototypes:
+//
+//	?method=POST&path~=/v1/users&createdAt[gte]=2024-01-01&sort=-updatedAt,name&offset=0&limit=50
+type ListPrototypesDTO struct {
+	Filters []ListFilterDTO
+	Sort    []string
+	Offset  int
+	Limit   int
+}
+
+// NewListPrototypesDTO interpreta el query string; los errores de sintaxis se reportan en Validate
+func NewListPrototypesDTO(query url.Values) (ListPrototypesDTO, error) {
+	dto := ListPrototypesDTO{Limit: DefaultListLimit}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		values := query[key]
+		var err error
+		switch key {
+		case "sort":
+			for _, value := range values {
+				for _, field := range strings.Split(value, ",") {
+					if field = strings.TrimSpace(field); field != "" {
+						dto.Sort = append(dto.Sort, field)
+					}
+				}
+			}
+		case "offset":
+			dto.Offset, err = strconv.Atoi(values[len(values)-1])
+		case "limit":
+			dto.Limit, err = strconv.Atoi(values[len(values)-1])
+		default:
+			field, operator, parseErr := parseListKey(key)
+			if parseErr != nil {
+				return dto, parseErr
+			}
+			for _, value := range values {
+				dto.Filters = append(dto.Filters, ListFilterDTO{Field: field, Operator: operator, Value: value})
+			}
+		}
+		if err != nil {
+			return dto, fmt.Errorf("%s must be an integer", key)
+		}
+	}
+
+	return dto, nil
+}
+
+func parseListKey(key string) (string, criteria.Operator, error) {
+	if open := strings.IndexByte(key, '['); open > 0 && strings.HasSuffix(key, "]") {
+		name := key[open+1 : len(key)-1]
+		operator, ok := listOperators[name]
+		if !ok {
+			return "", "", fmt.Errorf("unknown operator %q in %q (allowed: eq, ne, gt, gte, lt, lte, like, nlike, in, nin)", name, key)
+		}
+		return key[:open], operator, nil
+	}
+	for _, s := range listSuffixes {
+		if strings.HasSuffix(key, s.suffix) {
+			return strings.TrimSuffix(key, s.suffix), s.operator, nil
+		}
+	}
+	return key, criteria.OperatorEqual, nil
+}
+
+func (dto ListPrototypesDTO) Validate() error {
+
+	if dto.Offset < 0 {
+		return errors.New("offset must be >= 0")
+	}
+
+	if dto.Limit < 1 || dto.Limit > MaxListLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxListLimit)
+	}
+
+	for _, filter := range dto.Filters {
+		if _, ok := listFields[filter.Field]; !ok || filter.Field == "id" {
+			return fmt.Errorf("unknown filter %q (allowed: method, path, name, group, createdAt, updatedAt)", filter.Field)
+		}
+		if _, err := filter.value(); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range dto.Sort {
+		if _, ok := listFields[strings.TrimPrefix(field, "-")]; !ok {
+			return fmt.Errorf("unknown sort field %q (allowed: id, method, path, name, group, createdAt, updatedAt)", field)
+		}
+	}
+
+	return nil
+}
+
+// value convierte el texto del query al tipo del campo; in/nin llevan una lista separada por comas
+func (f ListFilterDTO) value() (any, error) {
+	convert := func(raw string) (any, error) {
+		if listDateFields[f.Field] {
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+				if t, err := time.Parse(layout, raw); err == nil {
+					return t, nil
+				}
+			}
+			return nil, fmt.Errorf("%s must be a date (RFC 3339 or YYYY-MM-DD), got %q", f.Field, raw)
+		}
+		if f.Field == "method" {
+			return strings.ToUpper(raw), nil
+		}
+		return raw, nil
+	}
+
+	switch f.Operator {
+	case criteria.OperatorLike, criteria.OperatorNotLike:
+		if listDateFields[f.Field] {
+			return nil, fmt.Errorf("%s does not support like", f.Field)
+		}
+		if _, err := regexp.Compile(f.Value); err != nil {
+			return nil, fmt.Errorf("%s: invalid regex %q", f.Field, f.Value)
+		}
+		return f.Value, nil
+	case criteria.OperatorIn, criteria.OperatorNotIn:
+		var values []any
+		for _, raw := range strings.Split(f.Value, ",") {
+			value, err := convert(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	return convert(f.Value)
+}
+
+func (dto ListPrototypesDTO) ToCommand() commands.ListPrototypesCommand {
+
+	filters := make([]criteria.Filter, 0, len(dto.Filters))
+	for _, filter := range dto.Filters {
+		value, _ := filter.value()
+		filters = append(filters, criteria.Filter{
+			Field:    listFields[filter.Field],
+			Operator: filter.Operator,
+			Value:    value,
+		})
+	}
+
+	orders := make([]criteria.Order, 0, len(dto.Sort)+1)
+	for _, field := range dto.Sort {
+		orders = append(orders, criteria.NewOrder(listFields[strings.TrimPrefix(field, "-")], strings.HasPrefix(field, "-")))
+	}
+	if len(orders) == 0 {
+		orders = append(orders, criteria.NewOrder("createdAt", false))
+	}
+
+	return commands.ListPrototypesCommand{
+		Criteria: criteria.Criteria{Filters: *criteria.NewFilters(filters), Orders: orders},
+		Offset:   dto.Offset,
+		Limit:    dto.Limit,
+	}
+}
@@ -0,0 +1,444 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Versiones de OpenAPI que acepta el import
+const (
+	OpenAPIVersion30 = "3.0"
+	OpenAPIVersion31 = "3.1"
+)
+
+// métodos de un path item, en el orden en que se generan los prototypes
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// headers que OpenAPI ignora como parámetros (los describen content y security)
+var openAPIIgnoredHeaders = map[string]bool{"accept": true, "content-type": true, "authorization": true}
+
+// OpenAPIDocumentDTO es el documento que recibe POST /v1/prototypes/import/openapi
+type OpenAPIDocumentDTO struct {
+	doc map[string]any
+
+	// BasePath se antepone a cada path; nil usa el path de servers[0].url
+	BasePath *string
+	// Group agrupa todos los prototypes; vacío usa el primer tag de cada operación
+	Group string
+}
+
+// DecodeOpenAPIDocumentDTO lee un documento OpenAPI JSON o YAML
+func DecodeOpenAPIDocumentDTO(body []byte, format string) (OpenAPIDocumentDTO, error) {
+	var dto OpenAPIDocumentDTO
+
+	if format == BundleFormatYAML {
+		raw, err := yamlToJSON(body)
+		if err != nil {
+			return dto, errors.New("invalid YAML document: " + err.Error())
+		}
+		body = raw
+	}
+
+	if err := json.Unmarshal(body, &dto.doc); err != nil {
+		return dto, errors.New("invalid JSON document: " + err.Error())
+	}
+	return dto, nil
+}
+
+// Version devuelve la versión mayor.menor del documento (3.0 o 3.1)
+func (dto OpenAPIDocumentDTO) Version() string {
+	version, _ := dto.doc["openapi"].(string)
+	for _, supported := range []string{OpenAPIVersion30, OpenAPIVersion31} {
+		if version == supported || strings.HasPrefix(version, supported+".") {
+			return supported
+		}
+	}
+	return ""
+}
+
+func (dto OpenAPIDocumentDTO) Validate() error {
+
+	if _, swagger := dto.doc["swagger"]; swagger {
+		return errors.New("swagger 2.0 documents are not supported, convert them to OpenAPI 3 first")
+	}
+
+	if dto.Version() == "" {
+		return fmt.Errorf("unsupported openapi version %v (supported: %s, %s)", dto.doc["openapi"], OpenAPIVersion30, OpenAPIVersion31)
+	}
+
+	paths, _ := dto.doc["paths"].(map[string]any)
+	if len(paths) == 0 {
+		return errors.New("paths is required")
+	}
+
+	if dto.BasePath != nil && *dto.BasePath != "" && !strings.HasPrefix(*dto.BasePath, "/") {
+		return errors.New("base_path must start with '/'")
+	}
+
+	return nil
+}
+
+// ToImportPrototypesDTO genera un prototype por operación. Lo que no se puede traducir
+// (refs externas, parámetros en cookies, respuestas que no son JSON) se omite y se
+// informa en los avisos.
+func (dto OpenAPIDocumentDTO) ToImportPrototypesDTO() (ImportPrototypesDTO, []string) {
+
+	converter := &openAPIConverter{doc: dto.doc, legacy: dto.Version() == OpenAPIVersion30}
+	basePath := dto.basePath()
+
+	paths, _ := dto.doc["paths"].(map[string]any)
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+
+	bundle := ImportPrototypesDTO{Version: BundleVersion}
+	for _, path := range keys {
+		item, ok := converter.resolve(paths[path])
+		if !ok {
+			converter.warn("%s: path item could not be resolved", path)
+			continue
+		}
+		for _, method := range openAPIMethods {
+			operation, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			bundle.Prototypes = append(bundle.Prototypes, converter.operation(basePath, dto.Group, path, method, item, operation))
+		}
+	}
+
+	return bundle, converter.warnings
+}
+
+// basePath es el prefijo de los urlPath: el explícito o el path de servers[0].url
+func (dto OpenAPIDocumentDTO) basePath() string {
+	if dto.BasePath != nil {
+		return strings.TrimRight(*dto.BasePath, "/")
+	}
+
+	servers, _ := dto.doc["servers"].([]any)
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]any)
+	raw, _ := server["url"].(string)
+
+	// {variable} -> default
+	variables, _ := server["variables"].(map[string]any)
+	for name, variable := range variables {
+		if definition, ok := variable.(map[string]any); ok {
+			raw = strings.ReplaceAll(raw, "{"+name+"}", fmt.Sprint(definition["default"]))
+		}
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(parsed.Path, "/")
+}
+
+// openAPIConverter traduce las operaciones de un documento; legacy activa las
+// diferencias de 3.0 con JSON Schema (nullable, exclusiveMinimum booleano)
+type openAPIConverter struct {
+	importWarnings
+
+	doc    map[string]any
+	legacy bool
+
+	// path params de la operación en curso, para responder {{path.<name>}} en los strings
+	pathParams map[string]bool
+}
+
+func (c *openAPIConverter) operation(basePath, group, path, method string, item, operation map[string]any) CreatePrototypeDTO {
+
+	where := strings.ToUpper(method) + " " + path
+
+	name, _ := operation["operationId"].(string)
+	if name == "" {
+		name = where
+	}
+	if group == "" {
+		if tags, ok := operation["tags"].([]any); ok && len(tags) > 0 {
+			group, _ = tags[0].(string)
+		}
+	}
+
+	request := RequestDTO{
+		Method:  strings.ToUpper(method),
+		UrlPath: basePath + path,
+	}
+
+	c.pathParams = map[string]bool{}
+	for _, parameter := range c.parameters(where, item, operation) {
+		c.applyParameter(where, &request, parameter)
+	}
+
+	if body, ok := c.resolve(operation["requestBody"]); ok {
+		if media, _ := c.jsonMedia(body["content"]); media != nil && media["schema"] != nil {
+			request.JSONSchema = c.jsonSchema(media["schema"], openAPIRequest)
+		}
+	}
+
+	return CreatePrototypeDTO{
+		Name:     name,
+		Group:    group,
+		Request:  request,
+		Response: c.response(where, operation),
+	}
+}
+
+// parameters junta los del path item y los de la operación (estos ganan), ordenados
+func (c *openAPIConverter) parameters(where string, item, operation map[string]any) []map[string]any {
+	merged := map[string]map[string]any{}
+	for _, source := range []any{item["parameters"], operation["parameters"]} {
+		list, _ := source.([]any)
+		for _, raw := range list {
+			parameter, ok := c.resolve(raw)
+			if !ok {
+				c.warn("%s: parameter could not be resolved", where)
+				continue
+			}
+			in, _ := parameter["in"].(string)
+			name, _ := parameter["name"].(string)
+			merged[in+" "+name] = parameter
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parameters := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		parameters = append(parameters, merged[key])
+	}
+	return parameters
+}
+
+// applyParameter: path params con tipo -> path_params (regex); query y headers requeridos
+// -> matchers; los opcionales -> query_schema / header_schema
+func (c *openAPIConverter) applyParameter(where string, request *RequestDTO, parameter map[string]any) {
+	in, _ := parameter["in"].(string)
+	name, _ := parameter["name"].(string)
+	required, _ := parameter["required"].(bool)
+
+	schema, _ := c.resolve(parameter["schema"])
+	if schema == nil {
+		if media, _ := c.jsonMedia(parameter["content"]); media != nil {
+			schema, _ = c.resolve(media["schema"])
+		}
+	}
+	if schema == nil {
+		schema = map[string]any{}
+	}
+
+	switch in {
+	case "path":
+		c.pathParams[name] = true
+		if pattern := c.valueRegex(where, schema); pattern != "" {
+			if request.PathParams == nil {
+				request.PathParams = map[string]string{}
+			}
+			request.PathParams[name] = pattern
+		}
+
+	case "query", "header":
+		if in == "header" && openAPIIgnoredHeaders[strings.ToLower(name)] {
+			return
+		}
+
+		if required {
+			matcher := c.requiredMatcher(where, schema)
+			if in == "query" {
+				if request.QueryMatchers == nil {
+					request.QueryMatchers = map[string]ValuesMatcherDTO{}
+				}
+				request.QueryMatchers[name] = matcher
+			} else {
+				if request.HeaderMatchers == nil {
+					request.HeaderMatchers = map[string]ValuesMatcherDTO{}
+				}
+				request.HeaderMatchers[name] = matcher
+			}
+			return
+		}
+
+		property, ok := c.paramProperty(name, schema)
+		if !ok {
+			c.warn("%s: %s parameter %q is not a scalar or an array of scalars, it is not validated", where, in, name)
+			return
+		}
+		if in == "query" {
+			request.QuerySchema = append(request.QuerySchema, property)
+		} else {
+			request.HeaderSchema = append(request.HeaderSchema, property)
+		}
+
+	default:
+		c.warn("%s: %s parameter %q is not supported, it is not validated", where, in, name)
+	}
+}
+
+// response arma la respuesta con el primer 2xx (o default) que tenga contenido JSON:
+// body del example/examples o generado a partir del schema, que además queda como contrato
+func (c *openAPIConverter) response(where string, operation map[string]any) ResponseDTO {
+	responses, _ := operation["responses"].(map[string]any)
+
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if _, ok := responses["default"]; ok {
+		codes = append(codes, "default")
+	}
+	if len(codes) == 0 {
+		c.warn("%s: no 2xx or default response, body left empty", where)
+		return ResponseDTO{}
+	}
+
+	// 2XX o default se responden con 200
+	dto := ResponseDTO{}
+	if code, err := strconv.Atoi(codes[0]); err == nil {
+		dto.StatusCode = code
+	}
+
+	response, ok := c.resolve(responses[codes[0]])
+	if !ok {
+		c.warn("%s: response %s could not be resolved, body left empty", where, codes[0])
+		return dto
+	}
+	content, _ := response["content"].(map[string]any)
+	if len(content) == 0 {
+		return dto
+	}
+	media, mediaType := c.jsonMedia(content)
+	if media == nil {
+		c.warn("%s: response %s has no JSON content (%s), body left empty", where, codes[0], strings.Join(sortedKeys(content), ", "))
+		return dto
+	}
+	if mediaType != "*/*" && !strings.HasPrefix(strings.ToLower(mediaType), "application/json") {
+		dto.Headers = map[string]string{"Content-Type": mediaType}
+	}
+
+	if media["schema"] != nil {
+		dto.JSONSchema = c.jsonSchema(media["schema"], openAPIResponse)
+	}
+	if example, ok := c.mediaExample(where, media); ok {
+		dto.Body = example
+	} else {
+		dto.Body = c.fake(media["schema"], "", 0)
+	}
+	return dto
+}
+
+// jsonMedia elige el media type JSON de un content: application/json, otro *json o */*
+func (c *openAPIConverter) jsonMedia(raw any) (map[string]any, string) {
+	content, _ := raw.(map[string]any)
+	keys := sortedKeys(content)
+
+	for _, accept := range []func(string) bool{
+		func(mediaType string) bool { return strings.HasPrefix(mediaType, "application/json") },
+		func(mediaType string) bool { return strings.Contains(mediaType, "json") },
+		func(mediaType string) bool { return mediaType == "*/*" },
+	} {
+		for _, key := range keys {
+			if accept(strings.ToLower(key)) {
+				media, _ := content[key].(map[string]any)
+				if media == nil {
+					media = map[string]any{}
+				}
+				return media, key
+			}
+		}
+	}
+	return nil, ""
+}
+
+// mediaExample: example, el primer examples (por nombre) o el example del schema
+func (c *openAPIConverter) mediaExample(where string, media map[string]any) (any, bool) {
+	if example, ok := media["example"]; ok {
+		return example, true
+	}
+
+	if examples, ok := media["examples"].(map[string]any); ok {
+		for _, name := range sortedKeys(examples) {
+			example, ok := c.resolve(examples[name])
+			if !ok {
+				continue
+			}
+			if value, ok := example["value"]; ok {
+				return value, true
+			}
+			if external, ok := example["externalValue"].(string); ok {
+				c.warn("%s: external example %q is not supported", where, external)
+			}
+		}
+	}
+
+	if schema, ok := c.resolve(media["schema"]); ok {
+		if example, ok := schema["example"]; ok {
+			return example, true
+		}
+		if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+			return examples[0], true
+		}
+	}
+
+	return nil, false
+}
+
+// resolve sigue los $ref locales (#/...) hasta el objeto; las refs externas no se soportan
+func (c *openAPIConverter) resolve(node any) (map[string]any, bool) {
+	object, ok := node.(map[string]any)
+	for depth := 0; ok && depth < 32; depth++ {
+		ref, isRef := object["$ref"].(string)
+		if !isRef {
+			return object, true
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			c.warn("external $ref %q is not supported", ref)
+			return nil, false
+		}
+		object, ok = c.pointer(ref).(map[string]any)
+	}
+	return nil, false
+}
+
+// pointer resuelve un JSON pointer local (#/components/schemas/User) contra el documento
+func (c *openAPIConverter) pointer(ref string) any {
+	var node any = c.doc
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = object[token]
+	}
+	return node
+}
+
+func sortedKeys[V any](object map[string]V) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
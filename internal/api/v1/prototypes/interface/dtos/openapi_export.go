@@ -0,0 +1,367 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	prototypes "mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIExportVersion es la versión del documento que genera GET /v1/prototypes/openapi.yaml
+const OpenAPIExportVersion = "3.1.0"
+
+// placeholder que ocupa todo el string: su tipo se conoce hasta renderizar
+var wholePlaceholderRe = regexp.MustCompile(`^\{\{[^{}]+\}\}$`)
+
+var operationIDRe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// OpenAPIExportDTO es el documento OpenAPI 3.1 armado con los prototypes registrados
+type OpenAPIExportDTO struct {
+	OpenAPI    string                                 `json:"openapi" yaml:"openapi"`
+	Info       OpenAPIInfoDTO                         `json:"info" yaml:"info"`
+	Servers    []OpenAPIServerDTO                     `json:"servers" yaml:"servers"`
+	Paths      map[string]map[string]OpenAPIOperation `json:"paths" yaml:"paths"`
+	Components *OpenAPIComponentsDTO                  `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type OpenAPIInfoDTO struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type OpenAPIServerDTO struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type OpenAPIComponentsDTO struct {
+	Schemas map[string]any `json:"schemas" yaml:"schemas"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                        `json:"operationId" yaml:"operationId"`
+	Summary     string                        `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Tags        []string                      `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameterDTO         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBodyDTO        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponseDTO `json:"responses" yaml:"responses"`
+}
+
+type OpenAPIParameterDTO struct {
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   map[string]any `json:"schema" yaml:"schema"`
+	Explode  *bool          `json:"explode,omitempty" yaml:"explode,omitempty"`
+}
+
+type OpenAPIRequestBodyDTO struct {
+	Required bool                       `json:"required" yaml:"required"`
+	Content  map[string]OpenAPIMediaDTO `json:"content" yaml:"content"`
+}
+
+type OpenAPIResponseDTO struct {
+	Description string                     `json:"description" yaml:"description"`
+	Content     map[string]OpenAPIMediaDTO `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type OpenAPIMediaDTO struct {
+	Schema  map[string]any `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example any            `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// NewOpenAPIExportDTO arma el documento; serverURL es donde responden los mocks (/v1/mocky)
+func NewOpenAPIExportDTO(models []prototypes.PrototypeModel, serverURL string, generatedAt time.Time) OpenAPIExportDTO {
+	document := OpenAPIExportDTO{
+		OpenAPI: OpenAPIExportVersion,
+		Info: OpenAPIInfoDTO{
+			Title:       "Mocky prototypes",
+			Version:     generatedAt.UTC().Format("2006.01.02-150405"),
+			Description: "Generated from the prototypes registered in mocky.",
+		},
+		Servers: []OpenAPIServerDTO{{URL: serverURL, Description: "mocky"}},
+		Paths:   map[string]map[string]OpenAPIOperation{},
+	}
+
+	components := map[string]any{}
+	operationIDs := map[string]bool{}
+
+	for _, model := range models {
+		path := openAPIPath(model.Request.UrlPath)
+		if document.Paths[path] == nil {
+			document.Paths[path] = map[string]OpenAPIOperation{}
+		}
+		operation := newOpenAPIOperation(model, components)
+		operation.OperationID = uniqueOperationID(operationIDs, model)
+		document.Paths[path][strings.ToLower(model.Request.Method)] = operation
+	}
+
+	if len(components) > 0 {
+		document.Components = &OpenAPIComponentsDTO{Schemas: components}
+	}
+	return document
+}
+
+// Encode serializa el documento en YAML (default) o JSON
+func (document OpenAPIExportDTO) Encode(format string) ([]byte, error) {
+	if format == BundleFormatJSON {
+		return json.MarshalIndent(document, "", "  ")
+	}
+	return yaml.Marshal(document)
+}
+
+func newOpenAPIOperation(model prototypes.PrototypeModel, components map[string]any) OpenAPIOperation {
+	request := model.Request
+
+	operation := OpenAPIOperation{
+		Summary:   model.Name,
+		Responses: map[string]OpenAPIResponseDTO{},
+	}
+	if model.Group != "" {
+		operation.Tags = []string{model.Group}
+	}
+
+	// path params: todos requeridos; con regex si el prototype la declara
+	for _, name := range request.PathParamNames() {
+		schema := map[string]any{"type": "string"}
+		if pattern, ok := openAPIPattern(request.PathParams[name]); ok {
+			schema["pattern"] = pattern
+		}
+		operation.Parameters = append(operation.Parameters, OpenAPIParameterDTO{Name: name, In: "path", Required: true, Schema: schema})
+	}
+
+	operation.Parameters = append(operation.Parameters, openAPIParameters("query", nil, request.QueryMatchers, request.QuerySchema)...)
+	operation.Parameters = append(operation.Parameters, openAPIParameters("header", request.Headers, request.HeaderMatchers, request.HeaderSchema)...)
+
+	if schema := requestJSONSchema(request, components); schema != nil {
+		operation.RequestBody = &OpenAPIRequestBodyDTO{
+			Required: true,
+			Content:  map[string]OpenAPIMediaDTO{"application/json": {Schema: schema}},
+		}
+	}
+
+	response := OpenAPIResponseDTO{Description: "Mocked response"}
+	if model.Response.Body != nil || model.Response.HasContract() {
+		mediaType := "application/json"
+		if contentType := headerValue(model.Response.Headers, "Content-Type"); contentType != "" {
+			mediaType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+		}
+		media := OpenAPIMediaDTO{Example: model.Response.Body}
+		switch {
+		case model.Response.JSONSchema != nil:
+			media.Schema = hoistComponents(model.Response.JSONSchema, components)
+		case model.Response.Schema != nil && model.Response.Schema.TypeSchema != "":
+			media.Schema = model.Response.Schema.JSONSchema()
+		default:
+			// sin contrato el tipo se deduce del body, para que los clientes generados tengan tipos
+			media.Schema, _ = inferJSONSchema(model.Response.Body).(map[string]any)
+		}
+		response.Content = map[string]OpenAPIMediaDTO{mediaType: media}
+	}
+	status := model.Response.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	if requestHasValidation(request) {
+		operation.Responses["422"] = OpenAPIResponseDTO{Description: "Request validation failed (path params, headers, query params or body)"}
+	}
+
+	return operation
+}
+
+// openAPIPattern quita el "^" con el que mocky marca un valor como regex; lo que queda ya se
+// evalúa como búsqueda, igual que pattern en OpenAPI, así que se exporta tal cual
+func openAPIPattern(value string) (string, bool) {
+	if !strings.HasPrefix(value, "^") {
+		return "", false
+	}
+	return value[1:], true
+}
+
+// openAPIParameters junta query params o headers: exactos (headers), matchers y schema tipado
+func openAPIParameters(in string, exact map[string]string, matchers map[string]entities.ValuesMatcherEntity, properties []entities.PropertyEntity) []OpenAPIParameterDTO {
+	byName := map[string]OpenAPIParameterDTO{}
+
+	for name, value := range exact {
+		schema := map[string]any{"type": "string", "const": value}
+		if pattern, ok := openAPIPattern(value); ok {
+			schema = map[string]any{"type": "string", "pattern": pattern}
+		}
+		byName[name] = OpenAPIParameterDTO{Name: name, In: in, Required: true, Schema: schema}
+	}
+
+	for name, matcher := range matchers {
+		value := map[string]any{"type": "string"}
+		if pattern, ok := openAPIPattern(matcher.Pattern); ok {
+			value["pattern"] = pattern
+		} else if matcher.Pattern != "" {
+			value["const"] = matcher.Pattern
+		}
+		schema := value
+		minimum := 0
+		switch {
+		case matcher.Count != nil:
+			minimum = *matcher.Count
+		case matcher.MinCount != nil:
+			minimum = *matcher.MinCount
+		}
+		if minimum > 1 || matcher.MaxCount != nil || matcher.Count != nil {
+			schema = map[string]any{"type": "array", "items": value}
+			if minimum > 0 {
+				schema["minItems"] = minimum
+			}
+			if matcher.Count != nil {
+				schema["maxItems"] = *matcher.Count
+			} else if matcher.MaxCount != nil {
+				schema["maxItems"] = *matcher.MaxCount
+			}
+		}
+		byName[name] = OpenAPIParameterDTO{Name: name, In: in, Required: minimum > 0, Schema: schema}
+	}
+
+	for _, property := range properties {
+		parameter := OpenAPIParameterDTO{Name: property.Name, In: in, Required: property.IsRequired, Schema: property.JSONSchema(true)}
+		if strings.ToLower(property.Type) == "array" {
+			explode := true
+			parameter.Explode = &explode
+		}
+		byName[property.Name] = parameter
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameters := make([]OpenAPIParameterDTO, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, byName[name])
+	}
+	return parameters
+}
+
+// requestJSONSchema: jsonSchema tal cual o el bodySchema traducido
+func requestJSONSchema(request entities.RequestEntity, components map[string]any) map[string]any {
+	if request.JSONSchema != nil {
+		return hoistComponents(request.JSONSchema, components)
+	}
+	if request.BodySchema != nil && request.BodySchema.TypeSchema != "" {
+		return request.BodySchema.JSONSchema()
+	}
+	return nil
+}
+
+// hoistComponents mueve los components.schemas embebidos en un jsonSchema (p. ej. los de
+// un import de OpenAPI) a los components del documento, donde resuelven sus $ref
+func hoistComponents(schema map[string]any, components map[string]any) map[string]any {
+	embedded, _ := schema["components"].(map[string]any)
+	schemas, _ := embedded["schemas"].(map[string]any)
+	if len(schemas) == 0 {
+		return schema
+	}
+
+	out := make(map[string]any, len(schema))
+	for key, value := range schema {
+		if key != "components" {
+			out[key] = value
+		}
+	}
+	for name, component := range schemas {
+		if _, exists := components[name]; !exists {
+			components[name] = component
+		}
+	}
+	return out
+}
+
+// inferJSONSchema deduce el schema de un body de ejemplo; un string que es solo un
+// placeholder puede resolver a cualquier tipo
+func inferJSONSchema(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		if _, partial := typed["$partial"]; partial {
+			return map[string]any{}
+		}
+		properties := make(map[string]any, len(typed))
+		for key, child := range typed {
+			properties[key] = inferJSONSchema(child)
+		}
+		return map[string]any{"type": "object", "properties": properties}
+	case []any:
+		schema := map[string]any{"type": "array"}
+		if len(typed) > 0 {
+			schema["items"] = inferJSONSchema(typed[0])
+		}
+		return schema
+	case string:
+		if wholePlaceholderRe.MatchString(strings.TrimSpace(typed)) {
+			return map[string]any{}
+		}
+		return map[string]any{"type": "string"}
+	case float64:
+		if typed == float64(int64(typed)) {
+			return map[string]any{"type": "integer"}
+		}
+		return map[string]any{"type": "number"}
+	case bool:
+		return map[string]any{"type": "boolean"}
+	case nil:
+		return map[string]any{"type": "null"}
+	}
+	return map[string]any{}
+}
+
+func requestHasValidation(request entities.RequestEntity) bool {
+	return len(request.PathParams) > 0 || len(request.Headers) > 0 ||
+		len(request.HeaderMatchers) > 0 || len(request.QueryMatchers) > 0 ||
+		len(request.QuerySchema) > 0 || len(request.HeaderSchema) > 0 ||
+		request.JSONSchema != nil || (request.BodySchema != nil && request.BodySchema.TypeSchema != "")
+}
+
+// openAPIPath cambia los segmentos :param por {param}
+func openAPIPath(urlPath string) string {
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// uniqueOperationID usa el nombre del prototype como operationId, sin repetir
+func uniqueOperationID(used map[string]bool, model prototypes.PrototypeModel) string {
+	base := strings.Trim(operationIDRe.ReplaceAllString(model.Name, "_"), "_")
+	if base == "" {
+		base = strings.Trim(operationIDRe.ReplaceAllString(strings.ToLower(model.Request.Method)+"_"+model.Request.UrlPath, "_"), "_")
+	}
+
+	id := base
+	for i := 2; used[id]; i++ {
+		id = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[id] = true
+	return id
+}
+
+// headerValue busca un header sin distinguir mayúsculas
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,546 @@
+package dtos
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	validator_controller "mocky/internal/context/controllers"
+)
+
+// Dirección de un schema: readOnly solo aplica a respuestas y writeOnly solo a requests
+const (
+	openAPIRequest  = "request"
+	openAPIResponse = "response"
+)
+
+// keywords cuyo valor es un dato y no un schema; se copian sin traducir
+var openAPILiteralKeywords = map[string]bool{
+	"example": true, "examples": true, "enum": true, "const": true, "default": true,
+	"discriminator": true, "xml": true, "externalDocs": true,
+}
+
+// keywords cuyo valor es un objeto nombre -> schema
+var openAPISchemaMapKeywords = map[string]bool{
+	"properties": true, "patternProperties": true, "dependentSchemas": true, "$defs": true, "definitions": true,
+}
+
+// Placeholders para los strings generados según su format
+var openAPIFormatPlaceholders = map[string]string{
+	"uuid":      "{{random.UUID}}",
+	"email":     "{{random.Email}}",
+	"date":      "{{random.Date}}",
+	"date-time": "{{random.Date(format:'2006-01-02T15:04:05Z')}}",
+	"uri":       "{{random.URL}}",
+	"url":       "{{random.URL}}",
+	"hostname":  "{{random.DomainName}}",
+	"ipv4":      "{{random.IPv4}}",
+	"ipv6":      "{{random.IPv6}}",
+	"password":  "{{random.Password}}",
+}
+
+// Placeholders para los strings generados según el nombre del campo
+var openAPIFieldPlaceholders = map[string]string{
+	"name":      "{{random.Name}}",
+	"fullname":  "{{random.Name}}",
+	"firstname": "{{random.FirstName}}",
+	"lastname":  "{{random.LastName}}",
+	"email":     "{{random.Email}}",
+	"phone":     "{{random.Phone}}",
+	"username":  "{{random.Username}}",
+}
+
+// Regex para validar path params, headers y query params según su format
+var openAPIFormatRegex = map[string]string{
+	"uuid":      `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"date":      `\d{4}-\d{2}-\d{2}`,
+	"date-time": `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})`,
+}
+
+// jsonSchema traduce un schema de OpenAPI a JSON Schema 2020-12. Las refs a
+// components.schemas se conservan y los schemas que usan se copian (traducidos) en
+// components.schemas del propio schema, así "#/components/schemas/User" resuelve igual.
+func (c *openAPIConverter) jsonSchema(schema any, direction string) map[string]any {
+	translation := &schemaTranslation{converter: c, direction: direction, components: map[string]any{}}
+
+	root, ok := translation.translate(schema, 0).(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	// closure de los components referenciados
+	for len(translation.pending) > 0 {
+		name := translation.pending[0]
+		translation.pending = translation.pending[1:]
+		component := c.pointer("#/components/schemas/" + name)
+		translation.components[name] = translation.translate(component, 0)
+	}
+
+	if len(translation.components) > 0 {
+		root["components"] = map[string]any{"schemas": translation.components}
+	}
+	return root
+}
+
+type schemaTranslation struct {
+	converter  *openAPIConverter
+	direction  string
+	components map[string]any
+	pending    []string
+}
+
+func (t *schemaTranslation) translate(node any, depth int) any {
+	switch typed := node.(type) {
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = t.translate(item, depth+1)
+		}
+		return out
+
+	case map[string]any:
+		if ref, ok := typed["$ref"].(string); ok {
+			return t.translateRef(ref, typed, depth)
+		}
+
+		out := make(map[string]any, len(typed))
+		for key, value := range typed {
+			switch schemas, isMap := value.(map[string]any); {
+			case openAPILiteralKeywords[key]:
+				out[key] = value
+			case openAPISchemaMapKeywords[key] && isMap:
+				translated := make(map[string]any, len(schemas))
+				for name, schema := range schemas {
+					translated[name] = t.translate(schema, depth+1)
+				}
+				out[key] = translated
+			default:
+				out[key] = t.translate(value, depth+1)
+			}
+		}
+
+		if t.converter.legacy {
+			legacySchema(out)
+		}
+		t.dropHiddenRequired(typed, out)
+		return out
+	}
+	return node
+}
+
+// translateRef conserva las refs a components.schemas; las demás refs locales se copian
+func (t *schemaTranslation) translateRef(ref string, node map[string]any, depth int) any {
+	const prefix = "#/components/schemas/"
+
+	if strings.HasPrefix(ref, prefix) {
+		name := strings.TrimPrefix(ref, prefix)
+		if _, seen := t.components[name]; !seen {
+			t.components[name] = nil
+			t.pending = append(t.pending, name)
+		}
+		if t.converter.legacy {
+			// en 3.0 lo que acompaña a un $ref se ignora
+			return map[string]any{"$ref": ref}
+		}
+		out := map[string]any{}
+		for key, value := range node {
+			out[key] = value
+		}
+		return out
+	}
+
+	if depth > 32 {
+		return map[string]any{}
+	}
+	resolved, ok := t.converter.resolve(node)
+	if !ok {
+		// sin la ref no hay nada que validar: acepta cualquier valor
+		return map[string]any{}
+	}
+	return t.translate(resolved, depth+1)
+}
+
+// dropHiddenRequired quita de required las propiedades readOnly (en requests) o
+// writeOnly (en respuestas): no se esperan en esa dirección
+func (t *schemaTranslation) dropHiddenRequired(original, out map[string]any) {
+	required, _ := out["required"].([]any)
+	properties, _ := original["properties"].(map[string]any)
+	if len(required) == 0 || len(properties) == 0 {
+		return
+	}
+
+	hidden := "readOnly"
+	if t.direction == openAPIResponse {
+		hidden = "writeOnly"
+	}
+
+	kept := make([]any, 0, len(required))
+	for _, name := range required {
+		property, _ := t.converter.resolve(properties[fmt.Sprint(name)])
+		if flag, _ := property[hidden].(bool); flag {
+			continue
+		}
+		kept = append(kept, name)
+	}
+	out["required"] = kept
+}
+
+// legacySchema traduce las diferencias de OpenAPI 3.0 con JSON Schema
+func legacySchema(schema map[string]any) {
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []any{typ, "null"}
+		}
+		if enum, ok := schema["enum"].([]any); ok {
+			schema["enum"] = append(append([]any{}, enum...), nil)
+		}
+	}
+	delete(schema, "nullable")
+
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		exclusive, ok := schema[bound[0]].(bool)
+		if !ok {
+			continue
+		}
+		delete(schema, bound[0])
+		if limit, hasLimit := schema[bound[1]]; exclusive && hasLimit {
+			schema[bound[0]] = limit
+			delete(schema, bound[1])
+		}
+	}
+}
+
+// schemaType devuelve el type del schema (el primero que no sea null) o lo deduce
+func schemaType(schema map[string]any) string {
+	switch typ := schema["type"].(type) {
+	case string:
+		return typ
+	case []any:
+		for _, candidate := range typ {
+			if s, ok := candidate.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+// valueRegex arma la regex ("^...") que valida un parámetro a partir de su schema:
+// const, enum, pattern, format o type. Vacío si cualquier valor es válido.
+func (c *openAPIConverter) valueRegex(where string, schema map[string]any) string {
+	if value, ok := schema["const"]; ok && value != nil {
+		return matcherRegex(regexp.QuoteMeta(fmt.Sprint(value)))
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		options := make([]string, 0, len(enum))
+		for _, value := range enum {
+			if value != nil {
+				options = append(options, regexp.QuoteMeta(fmt.Sprint(value)))
+			}
+		}
+		return matcherRegex(strings.Join(options, "|"))
+	}
+
+	// pattern de JSON Schema busca dentro del valor, igual que una regex de mocky
+	if pattern, ok := schema["pattern"].(string); ok && pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			c.warn("%s: pattern %q is not supported (%s), it is not validated", where, pattern, err.Error())
+			return ""
+		}
+		return "^" + pattern
+	}
+
+	if format, ok := schema["format"].(string); ok {
+		if pattern, ok := openAPIFormatRegex[format]; ok {
+			return matcherRegex(pattern)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "integer":
+		return matcherRegex(`-?\d+`)
+	case "number":
+		return matcherRegex(`-?\d+(?:\.\d+)?`)
+	case "boolean":
+		return matcherRegex(`true|false`)
+	}
+	return ""
+}
+
+// requiredMatcher exige al menos un valor; los arrays validan cada valor contra sus items
+func (c *openAPIConverter) requiredMatcher(where string, schema map[string]any) ValuesMatcherDTO {
+	one := 1
+	matcher := ValuesMatcherDTO{Pattern: "^.+", MinCount: &one}
+
+	if schemaType(schema) == "array" {
+		items, _ := c.resolve(schema["items"])
+		if pattern := c.valueRegex(where, items); pattern != "" {
+			matcher.Pattern = pattern
+		}
+		matcher.Match = "all"
+		return matcher
+	}
+
+	if pattern := c.valueRegex(where, schema); pattern != "" {
+		matcher.Pattern = pattern
+	}
+	return matcher
+}
+
+// paramProperty traduce el schema de un query param o header opcional a query_schema /
+// header_schema; solo escalares o arrays de escalares
+func (c *openAPIConverter) paramProperty(name string, schema map[string]any) (PropertyDTO, bool) {
+	property := PropertyDTO{Name: name}
+
+	switch typ := schemaType(schema); typ {
+	case "", "string", "integer", "number", "boolean":
+		property.Type = typ
+		if typ == "" {
+			property.Type = "string"
+		}
+		c.scalarProperty(schema, &property)
+
+	case "array":
+		items, _ := c.resolve(schema["items"])
+		itemsType := schemaType(items)
+		switch itemsType {
+		case "":
+			itemsType = "string"
+		case "string", "integer", "number", "boolean":
+		default:
+			return property, false
+		}
+		itemsProperty := PropertyDTO{Type: itemsType}
+		c.scalarProperty(items, &itemsProperty)
+
+		property.Type = "array"
+		property.Items = &itemsProperty
+		property.MinItems = int32Keyword(schema, "minItems")
+		property.MaxItems = int32Keyword(schema, "maxItems")
+		property.UniqueItems, _ = schema["uniqueItems"].(bool)
+
+	default:
+		return property, false
+	}
+
+	return property, true
+}
+
+func (c *openAPIConverter) scalarProperty(schema map[string]any, property *PropertyDTO) {
+	// solo formats que mocky conoce; int32, int64, double, ... son informativos
+	if format, ok := schema["format"].(string); ok && validator_controller.IsBuiltinFormat(format) {
+		property.Format = format
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err == nil {
+			property.Pattern = pattern
+		}
+	}
+	property.MinLength = int32Keyword(schema, "minLength")
+	property.MaxLength = int32Keyword(schema, "maxLength")
+	property.Minimum = floatKeyword(schema, "minimum")
+	property.Maximum = floatKeyword(schema, "maximum")
+	property.ExclusiveMinimum = floatKeyword(schema, "exclusiveMinimum")
+	property.ExclusiveMaximum = floatKeyword(schema, "exclusiveMaximum")
+	property.MultipleOf = floatKeyword(schema, "multipleOf")
+
+	if c.legacy {
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive {
+			property.ExclusiveMinimum, property.Minimum = property.Minimum, nil
+		}
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive {
+			property.ExclusiveMaximum, property.Maximum = property.Maximum, nil
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		property.Enum = enum
+	}
+	property.Default = schema["default"]
+}
+
+func int32Keyword(schema map[string]any, keyword string) int32 {
+	if value, ok := schema[keyword].(float64); ok {
+		return int32(value)
+	}
+	return 0
+}
+
+func floatKeyword(schema map[string]any, keyword string) *float64 {
+	if value, ok := schema[keyword].(float64); ok {
+		return &value
+	}
+	return nil
+}
+
+// fake genera un valor que cumple el schema, para las respuestas sin example. Los
+// strings usan placeholders ({{random.UUID}}, {{path.id}}, ...) para variar en cada llamada.
+func (c *openAPIConverter) fake(node any, field string, depth int) any {
+	schema, ok := c.resolve(node)
+	if !ok || depth > 8 {
+		return nil
+	}
+
+	for _, keyword := range []string{"example", "default", "const"} {
+		if value, ok := schema[keyword]; ok {
+			return value
+		}
+	}
+	if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	// composición: all_of se combina, de one_of/any_of se toma la primera rama
+	var branches []any
+	if allOf, ok := schema["allOf"].([]any); ok {
+		branches = append(branches, allOf...)
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[keyword].([]any); ok && len(options) > 0 {
+			branches = append(branches, options[0])
+			break
+		}
+	}
+	if len(branches) > 0 {
+		merged := map[string]any{}
+		for _, branch := range branches {
+			value := c.fake(branch, field, depth+1)
+			object, isObject := value.(map[string]any)
+			if !isObject {
+				return value
+			}
+			for key, fieldValue := range object {
+				merged[key] = fieldValue
+			}
+		}
+		if own, ok := c.fakeObject(schema, depth).(map[string]any); ok {
+			for key, value := range own {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return c.fakeObject(schema, depth)
+	case "array":
+		count := int(int32Keyword(schema, "minItems"))
+		if count == 0 {
+			count = 1
+		}
+		items := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			if item := c.fake(schema["items"], field, depth+1); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items
+	case "string":
+		return c.fakeString(schema, field)
+	case "integer":
+		return fakeNumber(schema, true)
+	case "number":
+		return fakeNumber(schema, false)
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+func (c *openAPIConverter) fakeObject(schema map[string]any, depth int) any {
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]any, len(names))
+	for _, name := range names {
+		property, _ := c.resolve(properties[name])
+		if writeOnly, _ := property["writeOnly"].(bool); writeOnly {
+			continue
+		}
+		out[name] = c.fake(properties[name], name, depth+1)
+	}
+	return out
+}
+
+// fakeString: placeholder según format, path param o nombre del campo; con límites
+// de longitud o pattern, un texto fijo que los cumpla
+func (c *openAPIConverter) fakeString(schema map[string]any, field string) string {
+	if format, ok := schema["format"].(string); ok {
+		if placeholder, ok := openAPIFormatPlaceholders[strings.ToLower(format)]; ok {
+			return placeholder
+		}
+	}
+	if c.pathParams[field] {
+		return "{{path." + field + "}}"
+	}
+
+	_, hasPattern := schema["pattern"]
+	minLength, maxLength := int(int32Keyword(schema, "minLength")), int(int32Keyword(schema, "maxLength"))
+	// los placeholders generan textos de al menos 2 caracteres
+	if !hasPattern && minLength <= 2 && maxLength == 0 {
+		normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(field))
+		if placeholder, ok := openAPIFieldPlaceholders[normalized]; ok {
+			return placeholder
+		}
+		return "{{random.Word}}"
+	}
+
+	s := "sample"
+	if len(s) < minLength {
+		s += strings.Repeat("x", minLength-len(s))
+	}
+	if maxLength > 0 && len(s) > maxLength {
+		s = s[:maxLength]
+	}
+	return s
+}
+
+func fakeNumber(schema map[string]any, integer bool) float64 {
+	n := 1.0
+	if minimum := floatKeyword(schema, "minimum"); minimum != nil {
+		n = *minimum
+	}
+	if exclusive := floatKeyword(schema, "exclusiveMinimum"); exclusive != nil && n <= *exclusive {
+		n = *exclusive + 1
+	}
+	if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive {
+		n++ // 3.0: minimum excluido
+	}
+	if maximum := floatKeyword(schema, "maximum"); maximum != nil && n > *maximum {
+		n = *maximum
+	}
+	if exclusive := floatKeyword(schema, "exclusiveMaximum"); exclusive != nil && n >= *exclusive {
+		n = *exclusive - 1
+	}
+	if maximum := floatKeyword(schema, "maximum"); maximum != nil && n >= *maximum {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive {
+			n = *maximum - 1
+		}
+	}
+	if multipleOf := floatKeyword(schema, "multipleOf"); multipleOf != nil && *multipleOf > 0 {
+		n = math.Ceil(n / *multipleOf) * *multipleOf
+	}
+	if integer {
+		n = math.Ceil(n)
+	}
+	return n
+}
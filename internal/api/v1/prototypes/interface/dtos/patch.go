@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"errors"
+	"mocky/internal/api/v1/prototypes/domain/commands"
+	"strconv"
+	"strings"
+)
+
+// PatchPrototypeDTO actualiza campos sueltos con rutas punteadas; los índices de arrays van como segmento:
+//
+//	{"response.body.name": "Ana", "request.delay": 300, "request.bodySchema.properties.0.max_length": 20}
+type PatchPrototypeDTO map[string]any
+
+func (dto PatchPrototypeDTO) Validate() error {
+	return dto.ToCommand("", false).Validate()
+}
+
+// ToCommand lleva las rutas al command; con markOverrides las rutas modificadas se marcan como overrides
+func (dto PatchPrototypeDTO) ToCommand(id string, markOverrides bool) commands.PatchPrototypeCommand {
+	return commands.PatchPrototypeCommand{
+		ID:            id,
+		Updates:       dto,
+		MarkOverrides: markOverrides,
+	}
+}
+
+// validateOverrides revisa que cada override sea una ruta punteada dentro del prototype
+func validateOverrides(overrides []string) error {
+	for _, path := range overrides {
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return errors.New("invalid path " + strconv.Quote(path))
+			}
+		}
+		if !commands.PatchableFields[segments[0]] || segments[0] == "overrides" {
+			return errors.New(strconv.Quote(path) + " is not a prototype field (allowed: request, response, name, group)")
+		}
+	}
+	return nil
+}
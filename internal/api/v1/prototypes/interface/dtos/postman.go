@@ -0,0 +1,242 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PostmanCollectionDTO es la colección (v2.1) que recibe POST /v1/prototypes/import/postman
+type PostmanCollectionDTO struct {
+	Info PostmanInfoDTO   `json:"info"`
+	Item []PostmanItemDTO `json:"item"`
+}
+
+type PostmanInfoDTO struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// PostmanItemDTO es una carpeta (item) o un request con sus ejemplos guardados (response)
+type PostmanItemDTO struct {
+	Name     string               `json:"name"`
+	Item     []PostmanItemDTO     `json:"item"`
+	Request  *PostmanRequestDTO   `json:"request"`
+	Response []PostmanResponseDTO `json:"response"`
+}
+
+// PostmanRequestDTO admite también la forma corta ("request": "https://...")
+type PostmanRequestDTO struct {
+	Method string        `json:"method"`
+	URL    PostmanURLDTO `json:"url"`
+}
+
+func (dto *PostmanRequestDTO) UnmarshalJSON(data []byte) error {
+	var raw string
+	if json.Unmarshal(data, &raw) == nil {
+		*dto = PostmanRequestDTO{Method: http.MethodGet, URL: PostmanURLDTO{Raw: raw}}
+		return nil
+	}
+	type plain PostmanRequestDTO
+	return json.Unmarshal(data, (*plain)(dto))
+}
+
+// PostmanURLDTO admite la url como string o como objeto (raw, host, path, query)
+type PostmanURLDTO struct {
+	Raw  string `json:"raw"`
+	Path any    `json:"path"`
+}
+
+func (dto *PostmanURLDTO) UnmarshalJSON(data []byte) error {
+	var raw string
+	if json.Unmarshal(data, &raw) == nil {
+		*dto = PostmanURLDTO{Raw: raw}
+		return nil
+	}
+	type plain PostmanURLDTO
+	return json.Unmarshal(data, (*plain)(dto))
+}
+
+// path devuelve el path sin host ni query: de url.path o, si no viene, de url.raw
+func (dto PostmanURLDTO) path() string {
+	switch typed := dto.Path.(type) {
+	case []any:
+		segments := make([]string, 0, len(typed))
+		for _, segment := range typed {
+			switch value := segment.(type) {
+			case string:
+				segments = append(segments, value)
+			case map[string]any:
+				segments = append(segments, fmt.Sprint(value["value"]))
+			}
+		}
+		return "/" + strings.Join(segments, "/")
+	case string:
+		return "/" + strings.TrimPrefix(typed, "/")
+	}
+
+	raw := dto.Raw
+	if end := strings.IndexAny(raw, "?#"); end >= 0 {
+		raw = raw[:end]
+	}
+	switch {
+	case strings.HasPrefix(raw, "{{"):
+		// {{baseUrl}}/users
+		if end := strings.Index(raw, "}}"); end >= 0 {
+			raw = raw[end+2:]
+		}
+	case strings.Contains(raw, "://"):
+		raw = raw[strings.Index(raw, "://")+3:]
+		fallthrough
+	case !strings.HasPrefix(raw, "/"):
+		// host sin esquema: api.example.com/users
+		if slash := strings.Index(raw, "/"); slash >= 0 {
+			raw = raw[slash:]
+		} else {
+			raw = "/"
+		}
+	}
+	return raw
+}
+
+// PostmanResponseDTO es un ejemplo guardado; originalRequest es el request que lo produjo
+type PostmanResponseDTO struct {
+	Name            string             `json:"name"`
+	OriginalRequest *PostmanRequestDTO `json:"originalRequest"`
+	Code            int                `json:"code"`
+	Header          any                `json:"header"`
+	Body            string             `json:"body"`
+}
+
+// DecodePostmanCollectionDTO lee una colección exportada de Postman (JSON)
+func DecodePostmanCollectionDTO(body []byte) (PostmanCollectionDTO, error) {
+	var dto PostmanCollectionDTO
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return dto, errors.New("invalid Postman collection: " + err.Error())
+	}
+	return dto, nil
+}
+
+func (dto PostmanCollectionDTO) Validate() error {
+
+	if dto.Info.Schema == "" {
+		return errors.New("info.schema is required, export the collection as Collection v2.1")
+	}
+
+	if !strings.Contains(dto.Info.Schema, "/v2.1") {
+		return fmt.Errorf("unsupported collection schema %q, export the collection as Collection v2.1", dto.Info.Schema)
+	}
+
+	if len(dto.Item) == 0 {
+		return errors.New("item is required")
+	}
+
+	return nil
+}
+
+// ToImportPrototypesDTO genera un prototype por cada ejemplo guardado (o por request, si no
+// tiene ejemplos). group vacío agrupa por la carpeta de primer nivel; los requests sueltos
+// quedan en el grupo con el nombre de la colección.
+func (dto PostmanCollectionDTO) ToImportPrototypesDTO(group string) (ImportPrototypesDTO, []string) {
+
+	converter := &captureConverter{}
+
+	rootGroup := group
+	if rootGroup == "" {
+		rootGroup = dto.Info.Name
+	}
+	exchanges := dto.exchanges(converter, dto.Item, rootGroup, group == "", "")
+
+	return ImportPrototypesDTO{Version: BundleVersion, Prototypes: converter.prototypes(exchanges)}, converter.warnings
+}
+
+// exchanges recorre las carpetas; folderGroups indica que la siguiente carpeta define el grupo
+func (dto PostmanCollectionDTO) exchanges(c *captureConverter, items []PostmanItemDTO, group string, folderGroups bool, where string) []capturedExchange {
+	var exchanges []capturedExchange
+
+	for _, item := range items {
+		itemWhere := item.Name
+		if where != "" {
+			itemWhere = where + " / " + item.Name
+		}
+
+		if item.Request == nil {
+			folderGroup := group
+			if folderGroups {
+				folderGroup = item.Name
+			}
+			exchanges = append(exchanges, dto.exchanges(c, item.Item, folderGroup, false, itemWhere)...)
+			continue
+		}
+
+		request := *item.Request
+		if len(item.Response) == 0 {
+			c.warn("%s: no saved example response, body left empty", itemWhere)
+			exchanges = append(exchanges, capturedExchange{
+				where:  itemWhere,
+				name:   item.Name,
+				group:  group,
+				method: postmanMethod(request.Method),
+				path:   request.URL.path(),
+				status: http.StatusOK,
+			})
+			continue
+		}
+
+		for _, example := range item.Response {
+			// el request del item trae las variables (:id); originalRequest, los valores usados
+			source := request
+			if source.URL.path() == "/" && example.OriginalRequest != nil {
+				source = *example.OriginalRequest
+			}
+			status := example.Code
+			if status == 0 {
+				status = http.StatusOK
+			}
+			exchanges = append(exchanges, capturedExchange{
+				where:   fmt.Sprintf("%s (%s)", itemWhere, example.Name),
+				name:    item.Name,
+				group:   group,
+				method:  postmanMethod(source.Method),
+				path:    source.URL.path(),
+				status:  status,
+				headers: postmanHeaders(example.Header),
+				body:    example.Body,
+			})
+		}
+	}
+	return exchanges
+}
+
+func postmanMethod(method string) string {
+	if method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(method)
+}
+
+// postmanHeaders lee los headers como lista [{key, value, disabled}] o como texto "K: v\n..."
+func postmanHeaders(raw any) map[string]string {
+	headers := map[string]string{}
+	switch typed := raw.(type) {
+	case []any:
+		for _, entry := range typed {
+			header, _ := entry.(map[string]any)
+			key, _ := header["key"].(string)
+			if disabled, _ := header["disabled"].(bool); key == "" || disabled {
+				continue
+			}
+			value, _ := header["value"].(string)
+			headers[key] = value
+		}
+	case string:
+		for _, line := range strings.Split(typed, "\n") {
+			if key, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(key) != "" {
+				headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return headers
+}
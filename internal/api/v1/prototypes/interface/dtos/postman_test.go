@@ -0,0 +1,25 @@
+package dtos
+
+import "testing"
+
+func TestImportPostman(t *testing.T) {
+	dto, err := DecodePostmanCollectionDTO(readImportFixture(t, "postman"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dto.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bundle, warnings := dto.ToImportPrototypesDTO("")
+	checkImportFixture(t, "postman", bundle, warnings)
+}
+
+func TestPostmanRejectsOldCollections(t *testing.T) {
+	dto, err := DecodePostmanCollectionDTO([]byte(`{"info": {"name": "x", "schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"}, "item": [{"name": "a", "request": "/a"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dto.Validate(); err == nil {
+		t.Fatal("expected a v2.0 collection to be rejected")
+	}
+}
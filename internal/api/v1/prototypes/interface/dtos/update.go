@@ -0,0 +1,20 @@
+package dtos
+
+import "mocky/internal/api/v1/prototypes/domain/commands"
+
+// UpdatePrototypeDTO reemplaza el prototype completo; las reglas son las mismas que al crearlo
+type UpdatePrototypeDTO struct {
+	CreatePrototypeDTO
+}
+
+func (dto UpdatePrototypeDTO) ToCommand(id string) commands.UpdatePrototypeCommand {
+	return commands.UpdatePrototypeCommand{
+		ID:       id,
+		Request:  dto.Request.ToEntity(),
+		Response: dto.Response.ToEntity(),
+		Name:     dto.Name,
+		Group:    dto.Group,
+
+		Overrides: dto.Overrides,
+	}
+}
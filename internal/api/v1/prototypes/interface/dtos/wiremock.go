@@ -0,0 +1,953 @@
+package dtos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	"mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// transformer de WireMock que habilita los templates handlebars en la respuesta
+const WireMockResponseTemplate = "response-template"
+
+// jsonPath simple: $.a.b, $.items[0].sku
+var wireMockJSONPathRe = regexp.MustCompile(`^\$((?:\.[A-Za-z_][A-Za-z0-9_-]*|\[\d+\])+)$`)
+
+// pasos de un JSON path simple: .campo o [índice]
+var wireMockJSONPathStepRe = regexp.MustCompile(`\.[A-Za-z_][A-Za-z0-9_-]*|\[\d+\]`)
+
+// índice máximo de un JSON path: el schema lleva un prefixItems por cada posición anterior
+const wireMockMaxJSONPathIndex = 100
+
+// {{request.path.x}}, {{{jsonPath request.body '$.a'}}}
+var handlebarsRe = regexp.MustCompile(`\{\{\{?\s*([^{}]+?)\s*\}?\}\}`)
+
+// {{path.id}}, {{ body.name }}
+var mockyPlaceholderRe = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// WireMockMappingsDTO es el archivo de mappings de WireMock (el de GET /__admin/mappings)
+type WireMockMappingsDTO struct {
+	Mappings []WireMockMappingDTO `json:"mappings"`
+	Meta     *WireMockMetaDTO     `json:"meta,omitempty"`
+}
+
+type WireMockMetaDTO struct {
+	Total int `json:"total"`
+}
+
+type WireMockMappingDTO struct {
+	Name     string              `json:"name,omitempty"`
+	Priority int                 `json:"priority,omitempty"`
+	Request  WireMockRequestDTO  `json:"request"`
+	Response WireMockResponseDTO `json:"response"`
+	Metadata map[string]any      `json:"metadata,omitempty"`
+
+	// escenarios: mocky no guarda estado entre requests
+	ScenarioName string `json:"scenarioName,omitempty"`
+}
+
+type WireMockRequestDTO struct {
+	Method          string                        `json:"method,omitempty"`
+	URL             string                        `json:"url,omitempty"`
+	URLPath         string                        `json:"urlPath,omitempty"`
+	URLPathPattern  string                        `json:"urlPathPattern,omitempty"`
+	URLPattern      string                        `json:"urlPattern,omitempty"`
+	URLPathTemplate string                        `json:"urlPathTemplate,omitempty"`
+	PathParameters  map[string]WireMockMatcherDTO `json:"pathParameters,omitempty"`
+	QueryParameters map[string]WireMockMatcherDTO `json:"queryParameters,omitempty"`
+	Headers         map[string]WireMockMatcherDTO `json:"headers,omitempty"`
+	Cookies         map[string]WireMockMatcherDTO `json:"cookies,omitempty"`
+	BodyPatterns    []WireMockMatcherDTO          `json:"bodyPatterns,omitempty"`
+}
+
+// WireMockMatcherDTO es un matcher de WireMock: {"equalTo": "x"}, {"matches": "^a"}, ...
+type WireMockMatcherDTO map[string]any
+
+type WireMockResponseDTO struct {
+	Status                 int            `json:"status,omitempty"`
+	Headers                map[string]any `json:"headers,omitempty"`
+	JSONBody               any            `json:"jsonBody,omitempty"`
+	Body                   any            `json:"body,omitempty"`
+	Base64Body             string         `json:"base64Body,omitempty"`
+	BodyFileName           string         `json:"bodyFileName,omitempty"`
+	FixedDelayMilliseconds int            `json:"fixedDelayMilliseconds,omitempty"`
+	DelayDistribution      map[string]any `json:"delayDistribution,omitempty"`
+	Transformers           []string       `json:"transformers,omitempty"`
+	Fault                  string         `json:"fault,omitempty"`
+	ProxyBaseURL           string         `json:"proxyBaseUrl,omitempty"`
+}
+
+// DecodeWireMockMappingsDTO acepta un mapping suelto, un array de mappings o {"mappings": [...]}
+func DecodeWireMockMappingsDTO(body []byte) (WireMockMappingsDTO, error) {
+	var dto WireMockMappingsDTO
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &dto.Mappings); err != nil {
+			return dto, errors.New("invalid JSON mappings: " + err.Error())
+		}
+		return dto, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return dto, errors.New("invalid JSON mappings: " + err.Error())
+	}
+	if _, single := probe["request"]; single {
+		var mapping WireMockMappingDTO
+		if err := json.Unmarshal(body, &mapping); err != nil {
+			return dto, errors.New("invalid JSON mapping: " + err.Error())
+		}
+		dto.Mappings = []WireMockMappingDTO{mapping}
+		return dto, nil
+	}
+
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return dto, errors.New("invalid JSON mappings: " + err.Error())
+	}
+	return dto, nil
+}
+
+func (dto WireMockMappingsDTO) Validate() error {
+
+	if len(dto.Mappings) == 0 {
+		return errors.New("mappings is required")
+	}
+
+	return nil
+}
+
+// ToImportPrototypesDTO genera un prototype por mapping. Los mappings que mocky no puede
+// reproducir (método ANY, faults, proxies, url regex sin forma de path) se omiten; los
+// matchers y helpers sin equivalente se ignoran. Ambos casos se informan en los avisos.
+func (dto WireMockMappingsDTO) ToImportPrototypesDTO(group string) (ImportPrototypesDTO, []string) {
+
+	converter := &wireMockConverter{}
+
+	bundle := ImportPrototypesDTO{Version: BundleVersion}
+	for i, mapping := range dto.Mappings {
+		if prototype, ok := converter.mapping(i, group, mapping); ok {
+			bundle.Prototypes = append(bundle.Prototypes, prototype)
+		}
+	}
+
+	return bundle, converter.warnings
+}
+
+type wireMockConverter struct {
+	importWarnings
+
+	// segmentos del path del mapping en curso, para {{request.path.[N]}}
+	segments []string
+}
+
+func (c *wireMockConverter) mapping(i int, group string, mapping WireMockMappingDTO) (CreatePrototypeDTO, bool) {
+
+	where := fmt.Sprintf("mappings[%d]", i)
+	if mapping.Name != "" {
+		where += " (" + mapping.Name + ")"
+	}
+
+	method := strings.ToUpper(mapping.Request.Method)
+	if method == "" || method == "ANY" {
+		c.warn("%s: method %q is not supported, mapping skipped", where, mapping.Request.Method)
+		return CreatePrototypeDTO{}, false
+	}
+	if mapping.Response.Fault != "" || mapping.Response.ProxyBaseURL != "" {
+		c.warn("%s: faults and proxies are not supported, mapping skipped", where)
+		return CreatePrototypeDTO{}, false
+	}
+
+	request := RequestDTO{Method: method, Delay: mapping.Response.FixedDelayMilliseconds}
+	if !c.path(where, mapping.Request, &request) {
+		return CreatePrototypeDTO{}, false
+	}
+	c.segments = strings.Split(strings.Trim(request.UrlPath, "/"), "/")
+
+	for _, name := range sortedKeys(mapping.Request.Headers) {
+		pattern, absent, ok := c.matcherPattern(where, "header "+name, mapping.Request.Headers[name])
+		switch {
+		case absent:
+			none := 0
+			if request.HeaderMatchers == nil {
+				request.HeaderMatchers = map[string]ValuesMatcherDTO{}
+			}
+			request.HeaderMatchers[name] = ValuesMatcherDTO{MaxCount: &none}
+		case ok:
+			if request.Headers == nil {
+				request.Headers = map[string]string{}
+			}
+			request.Headers[name] = pattern
+		}
+	}
+
+	for _, name := range sortedKeys(mapping.Request.QueryParameters) {
+		pattern, absent, ok := c.matcherPattern(where, "query parameter "+name, mapping.Request.QueryParameters[name])
+		if !absent && !ok {
+			continue
+		}
+		if request.QueryMatchers == nil {
+			request.QueryMatchers = map[string]ValuesMatcherDTO{}
+		}
+		request.QueryMatchers[name] = queryValuesMatcher(pattern, absent)
+	}
+
+	if len(mapping.Request.Cookies) > 0 {
+		c.warn("%s: cookie matchers are not supported, they are not validated", where)
+	}
+
+	request.JSONSchema = c.bodySchema(where, mapping.Request.BodyPatterns)
+
+	if mapping.Priority != 0 || mapping.ScenarioName != "" {
+		c.warn("%s: priority and scenarios are ignored", where)
+	}
+
+	name := mapping.Name
+	if name == "" {
+		name = method + " " + request.UrlPath
+	}
+	if group == "" {
+		if mocky, ok := mapping.Metadata["mocky"].(map[string]any); ok {
+			group, _ = mocky["group"].(string)
+		}
+	}
+
+	return CreatePrototypeDTO{
+		Name:     name,
+		Group:    group,
+		Request:  request,
+		Response: c.response(where, mapping.Response),
+	}, true
+}
+
+// path traduce url, urlPath, urlPathTemplate o las variantes regex a un urlPath de mocky
+func (c *wireMockConverter) path(where string, source WireMockRequestDTO, request *RequestDTO) bool {
+	switch {
+	case source.URLPathTemplate != "":
+		request.UrlPath = source.URLPathTemplate
+		for _, name := range sortedKeys(source.PathParameters) {
+			pattern, _, ok := c.matcherPattern(where, "path parameter "+name, source.PathParameters[name])
+			if !ok {
+				continue
+			}
+			if request.PathParams == nil {
+				request.PathParams = map[string]string{}
+			}
+			request.PathParams[name] = pattern
+		}
+
+	case source.URLPath != "":
+		request.UrlPath = source.URLPath
+
+	case source.URL != "":
+		parsed, err := url.Parse(source.URL)
+		if err != nil {
+			c.warn("%s: url %q is invalid, mapping skipped", where, source.URL)
+			return false
+		}
+		request.UrlPath = parsed.Path
+		query := parsed.Query()
+		for _, name := range sortedKeys(query) {
+			values := query[name]
+			if len(values) > 1 {
+				c.warn("%s: query parameter %q repeats, only its first value is matched", where, name)
+			}
+			if request.QueryMatchers == nil {
+				request.QueryMatchers = map[string]ValuesMatcherDTO{}
+			}
+			request.QueryMatchers[name] = queryValuesMatcher(exactPattern(values[0]), false)
+		}
+
+	case source.URLPathPattern != "" || source.URLPattern != "":
+		pattern := source.URLPathPattern
+		if pattern == "" {
+			pattern = source.URLPattern
+			if before, _, query := strings.Cut(pattern, `\?`); query {
+				c.warn("%s: the query part of urlPattern is ignored, use queryParameters", where)
+				pattern = before
+			}
+		}
+		urlPath, params, ok := pathTemplateFromRegex(pattern)
+		if !ok {
+			c.warn("%s: url pattern %q cannot be expressed as a path template, mapping skipped", where, pattern)
+			return false
+		}
+		for _, segment := range params {
+			if strings.Contains(segment, ".*") || strings.Contains(segment, ".+") {
+				c.warn("%s: url pattern %q only matches a single path segment per parameter", where, pattern)
+				break
+			}
+		}
+		request.UrlPath = urlPath
+		request.PathParams = params
+
+	default:
+		c.warn("%s: request has no url, mapping skipped", where)
+		return false
+	}
+
+	if !strings.HasPrefix(request.UrlPath, "/") {
+		request.UrlPath = "/" + request.UrlPath
+	}
+	return true
+}
+
+// matcherPattern traduce un matcher de texto a la convención de mocky (valor exacto o regex
+// con "^"). absent indica {"absent": true}; ok es false si el matcher no tiene equivalente.
+func (c *wireMockConverter) matcherPattern(where, subject string, matcher WireMockMatcherDTO) (pattern string, absent bool, ok bool) {
+
+	caseInsensitive, _ := matcher["caseInsensitive"].(bool)
+	anchored := func(expr string) string {
+		if caseInsensitive {
+			return matcherRegex("(?i:" + expr + ")")
+		}
+		return matcherRegex(expr)
+	}
+
+	switch {
+	case matcher["equalTo"] != nil:
+		value := fmt.Sprint(matcher["equalTo"])
+		if caseInsensitive {
+			return anchored(regexp.QuoteMeta(value)), false, true
+		}
+		return exactPattern(value), false, true
+
+	case matcher["contains"] != nil:
+		return anchored(".*" + regexp.QuoteMeta(fmt.Sprint(matcher["contains"])) + ".*"), false, true
+
+	case matcher["matches"] != nil:
+		expr := fmt.Sprint(matcher["matches"])
+		if _, err := regexp.Compile(expr); err != nil {
+			c.warn("%s: %s regex %q is not supported (%s), it is not validated", where, subject, expr, err.Error())
+			return "", false, false
+		}
+		return anchored(expr), false, true
+
+	case matcher["absent"] == true:
+		return "", true, false
+	}
+
+	keys := make([]string, 0, len(matcher))
+	for key := range matcher {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	c.warn("%s: %s matcher %s is not supported, it is not validated", where, subject, strings.Join(keys, ", "))
+	return "", false, false
+}
+
+// bodySchema junta los bodyPatterns JSON en un jsonSchema (allOf si hay varios)
+func (c *wireMockConverter) bodySchema(where string, patterns []WireMockMatcherDTO) map[string]any {
+	var schemas []any
+
+	for _, pattern := range patterns {
+		switch {
+		case pattern["matchesJsonSchema"] != nil:
+			schema, ok := jsonValue(pattern["matchesJsonSchema"]).(map[string]any)
+			if !ok {
+				c.warn("%s: matchesJsonSchema is not a JSON object, it is not validated", where)
+				continue
+			}
+			// {"schemaVersion": "...", "schema": {...}} como en el ejemplo de entities
+			if inner, ok := schema["schema"].(map[string]any); ok && schema["schemaVersion"] != nil {
+				schema = inner
+			}
+			schemas = append(schemas, schema)
+
+		case pattern["equalToJson"] != nil:
+			value := jsonValue(pattern["equalToJson"])
+			if ignoreOrder, _ := pattern["ignoreArrayOrder"].(bool); ignoreOrder {
+				c.warn("%s: ignoreArrayOrder is not supported, array order is still enforced", where)
+			}
+			if ignoreExtra, _ := pattern["ignoreExtraElements"].(bool); ignoreExtra {
+				schemas = append(schemas, partialJSONSchema(value))
+			} else {
+				schemas = append(schemas, map[string]any{"const": value})
+			}
+
+		case pattern["matchesJsonPath"] != nil:
+			if schema, ok := c.jsonPathSchema(where, pattern["matchesJsonPath"]); ok {
+				schemas = append(schemas, schema)
+			}
+
+		default:
+			keys := make([]string, 0, len(pattern))
+			for key := range pattern {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			c.warn("%s: body pattern %s is not supported, it is not validated", where, strings.Join(keys, ", "))
+		}
+	}
+
+	switch len(schemas) {
+	case 0:
+		return nil
+	case 1:
+		return schemas[0].(map[string]any)
+	}
+	return map[string]any{"allOf": schemas}
+}
+
+// jsonPathSchema: "$.a.b" exige la ruta; {"expression": "$.a", "equalTo": "x"} además su valor
+func (c *wireMockConverter) jsonPathSchema(where string, raw any) (map[string]any, bool) {
+
+	expression, leaf := "", map[string]any{}
+	switch typed := raw.(type) {
+	case string:
+		expression = typed
+	case map[string]any:
+		expression, _ = typed["expression"].(string)
+		switch {
+		case typed["equalTo"] != nil:
+			leaf["const"] = typed["equalTo"]
+		case typed["matches"] != nil:
+			// WireMock compara el valor completo; pattern de JSON Schema busca dentro
+			leaf["pattern"] = "^(?:" + fmt.Sprint(typed["matches"]) + ")$"
+		case typed["contains"] != nil:
+			leaf["pattern"] = regexp.QuoteMeta(fmt.Sprint(typed["contains"]))
+		default:
+			c.warn("%s: matchesJsonPath %q uses an unsupported matcher, only the path is required", where, expression)
+		}
+	}
+
+	match := wireMockJSONPathRe.FindStringSubmatch(expression)
+	if match == nil {
+		c.warn("%s: matchesJsonPath %q is not a simple path, it is not validated", where, expression)
+		return nil, false
+	}
+
+	steps := wireMockJSONPathStepRe.FindAllString(match[1], -1)
+	schema := leaf
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		if strings.HasPrefix(step, "[") {
+			index, err := strconv.Atoi(strings.Trim(step, "[]"))
+			if err != nil || index > wireMockMaxJSONPathIndex {
+				c.warn("%s: matchesJsonPath %q uses index %s (maximum %d), it is not validated", where, expression, step, wireMockMaxJSONPathIndex)
+				return nil, false
+			}
+			prefix := make([]any, index+1)
+			for j := range prefix {
+				prefix[j] = map[string]any{}
+			}
+			prefix[index] = schema
+			schema = map[string]any{"type": "array", "prefixItems": prefix, "minItems": index + 1}
+			continue
+		}
+		name := step[1:]
+		schema = map[string]any{
+			"type":       "object",
+			"required":   []any{name},
+			"properties": map[string]any{name: schema},
+		}
+	}
+	return schema, true
+}
+
+// response traduce status, headers y body; con el transformer response-template los
+// helpers handlebars se cambian por placeholders de mocky
+func (c *wireMockConverter) response(where string, source WireMockResponseDTO) ResponseDTO {
+	dto := ResponseDTO{StatusCode: source.Status}
+
+	for _, name := range sortedKeys(source.Headers) {
+		if dto.Headers == nil {
+			dto.Headers = map[string]string{}
+		}
+		switch value := source.Headers[name].(type) {
+		case []any:
+			parts := make([]string, 0, len(value))
+			for _, part := range value {
+				parts = append(parts, fmt.Sprint(part))
+			}
+			dto.Headers[name] = strings.Join(parts, ", ")
+		default:
+			dto.Headers[name] = fmt.Sprint(value)
+		}
+	}
+
+	switch {
+	case source.JSONBody != nil:
+		dto.Body = source.JSONBody
+	case source.Body != nil:
+		dto.Body = jsonValue(source.Body)
+		if _, text := dto.Body.(string); text && headerValue(dto.Headers, "Content-Type") == "" {
+			if dto.Headers == nil {
+				dto.Headers = map[string]string{}
+			}
+			dto.Headers["Content-Type"] = "text/plain"
+		}
+	case source.Base64Body != "" || source.BodyFileName != "":
+		c.warn("%s: base64Body and bodyFileName are not supported, body left empty", where)
+	}
+
+	if source.DelayDistribution != nil {
+		c.warn("%s: delayDistribution is not supported, use fixedDelayMilliseconds", where)
+	}
+
+	templated := false
+	for _, transformer := range source.Transformers {
+		if transformer == WireMockResponseTemplate {
+			templated = true
+		} else {
+			c.warn("%s: transformer %q is not supported", where, transformer)
+		}
+	}
+	if templated {
+		dto.Body = c.templateValue(where, dto.Body)
+		for _, name := range sortedKeys(dto.Headers) {
+			dto.Headers[name] = c.template(where, dto.Headers[name])
+		}
+	}
+
+	return dto
+}
+
+func (c *wireMockConverter) templateValue(where string, node any) any {
+	switch typed := node.(type) {
+	case map[string]any:
+		// en orden, para que los avisos salgan siempre igual
+		for _, key := range sortedKeys(typed) {
+			typed[key] = c.templateValue(where, typed[key])
+		}
+		return typed
+	case []any:
+		for i, value := range typed {
+			typed[i] = c.templateValue(where, value)
+		}
+		return typed
+	case string:
+		return c.template(where, typed)
+	}
+	return node
+}
+
+// template cambia los helpers handlebars de WireMock por placeholders de mocky
+func (c *wireMockConverter) template(where, text string) string {
+	return handlebarsRe.ReplaceAllStringFunc(text, func(match string) string {
+		expr := handlebarsRe.FindStringSubmatch(match)[1]
+		if translated, ok := c.helper(where, expr); ok {
+			return translated
+		}
+		c.warn("%s: template helper {{%s}} has no mocky equivalent, it is kept as is", where, expr)
+		return match
+	})
+}
+
+func (c *wireMockConverter) helper(where, expr string) (string, bool) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	switch fields[0] {
+	case "jsonPath":
+		if len(fields) != 3 || fields[1] != "request.body" {
+			return "", false
+		}
+		path := strings.Trim(fields[2], `'"`)
+		if !wireMockJSONPathRe.MatchString(path) {
+			return "", false
+		}
+		return "{{body." + strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".") + "}}", true
+
+	case "randomValue":
+		if strings.Contains(expr, "'UUID'") || strings.Contains(expr, `"UUID"`) {
+			return "{{random.UUID}}", true
+		}
+		return "", false
+
+	case "now":
+		if len(fields) > 1 {
+			c.warn("%s: {{%s}} is translated to {{request.receivedAt}} (RFC 3339), its arguments are ignored", where, expr)
+		}
+		return "{{request.receivedAt}}", true
+	}
+
+	if len(fields) > 1 {
+		return "", false
+	}
+	name := fields[0]
+	switch name {
+	case "request.url", "request.method", "request.path", "request.host", "request.clientIp":
+		return "{{" + name + "}}", true
+	case "request.body":
+		return "{{request.rawBody}}", true
+	}
+
+	for _, prefix := range []string{"request.pathSegments.", "request.path."} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+		index, err := strconv.Atoi(strings.Trim(rest, "[]"))
+		if err != nil {
+			return "{{path." + rest + "}}", true
+		}
+		if index < 0 || index >= len(c.segments) {
+			return "", false
+		}
+		if param, ok := pathSegmentParam(c.segments[index]); ok {
+			return "{{path." + param + "}}", true
+		}
+		return c.segments[index], true
+	}
+
+	if rest, ok := strings.CutPrefix(name, "request.query."); ok {
+		return "{{query." + rest + "}}", true
+	}
+	if rest, ok := strings.CutPrefix(name, "request.headers."); ok {
+		return "{{headers." + strings.TrimSuffix(strings.TrimPrefix(rest, "["), "]") + "}}", true
+	}
+	return "", false
+}
+
+// NewWireMockMappingsDTO exporta los prototypes como mappings de WireMock. Lo que WireMock
+// no puede expresar (query_schema, header_schema, conteos de los matchers, placeholders sin
+// helper equivalente) se omite y se informa en los avisos.
+func NewWireMockMappingsDTO(models []prototypes.PrototypeModel) (WireMockMappingsDTO, []string) {
+	var warnings []string
+
+	mappings := make([]WireMockMappingDTO, 0, len(models))
+	for _, model := range models {
+		mapping, mappingWarnings := newWireMockMapping(model)
+		mappings = append(mappings, mapping)
+		warnings = append(warnings, mappingWarnings...)
+	}
+
+	return WireMockMappingsDTO{Mappings: mappings, Meta: &WireMockMetaDTO{Total: len(mappings)}}, warnings
+}
+
+func newWireMockMapping(model prototypes.PrototypeModel) (WireMockMappingDTO, []string) {
+	var warnings []string
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, model.Name+": "+fmt.Sprintf(format, args...))
+	}
+
+	request := model.Request
+	mapping := WireMockMappingDTO{
+		Name:    model.Name,
+		Request: WireMockRequestDTO{Method: strings.ToUpper(request.Method)},
+	}
+
+	if names := request.PathParamNames(); len(names) > 0 {
+		mapping.Request.URLPathTemplate = openAPIPath(request.UrlPath)
+		for _, name := range names {
+			if pattern, ok := request.PathParams[name]; ok && pattern != "" {
+				if mapping.Request.PathParameters == nil {
+					mapping.Request.PathParameters = map[string]WireMockMatcherDTO{}
+				}
+				mapping.Request.PathParameters[name] = wireMockMatcher(pattern)
+			}
+		}
+	} else {
+		mapping.Request.URLPath = request.UrlPath
+	}
+
+	for name, pattern := range request.Headers {
+		if mapping.Request.Headers == nil {
+			mapping.Request.Headers = map[string]WireMockMatcherDTO{}
+		}
+		mapping.Request.Headers[name] = wireMockMatcher(pattern)
+	}
+	for name, matcher := range request.HeaderMatchers {
+		if mapping.Request.Headers == nil {
+			mapping.Request.Headers = map[string]WireMockMatcherDTO{}
+		}
+		mapping.Request.Headers[name] = wireMockValuesMatcher(matcher, func() { warn("counts of header %q are not exported", name) })
+	}
+	for name, matcher := range request.QueryMatchers {
+		if mapping.Request.QueryParameters == nil {
+			mapping.Request.QueryParameters = map[string]WireMockMatcherDTO{}
+		}
+		mapping.Request.QueryParameters[name] = wireMockValuesMatcher(matcher, func() { warn("counts of query parameter %q are not exported", name) })
+	}
+	if len(request.QuerySchema) > 0 || len(request.HeaderSchema) > 0 {
+		warn("query_schema and header_schema are not exported")
+	}
+
+	schema := request.JSONSchema
+	if schema == nil && request.BodySchema != nil && request.BodySchema.TypeSchema != "" {
+		schema = request.BodySchema.JSONSchema()
+	}
+	if schema != nil {
+		mapping.Request.BodyPatterns = []WireMockMatcherDTO{{"matchesJsonSchema": schema, "schemaVersion": "V202012"}}
+	}
+
+	response := model.Response
+	mapping.Response = WireMockResponseDTO{
+		Status:                 response.StatusCode,
+		FixedDelayMilliseconds: request.Delay,
+	}
+	if mapping.Response.Status == 0 {
+		mapping.Response.Status = http.StatusOK
+	}
+
+	templated := false
+	translate := func(text string) string {
+		return mockyPlaceholderRe.ReplaceAllStringFunc(text, func(match string) string {
+			helper, ok := wireMockHelper(mockyPlaceholderRe.FindStringSubmatch(match)[1])
+			if !ok {
+				warn("placeholder %s has no WireMock equivalent", match)
+				return match
+			}
+			templated = true
+			return helper
+		})
+	}
+
+	for name, value := range response.Headers {
+		if mapping.Response.Headers == nil {
+			mapping.Response.Headers = map[string]any{}
+		}
+		mapping.Response.Headers[name] = translate(value)
+	}
+	if text, ok := response.Body.(string); ok {
+		mapping.Response.Body = translate(text)
+	} else if response.Body != nil {
+		mapping.Response.JSONBody = mapStrings(response.Body, translate)
+	}
+	if templated {
+		mapping.Response.Transformers = []string{WireMockResponseTemplate}
+	}
+
+	if model.Group != "" {
+		mapping.Metadata = map[string]any{"mocky": map[string]any{"group": model.Group}}
+	}
+
+	return mapping, warnings
+}
+
+// wireMockHelper traduce un placeholder de mocky al helper handlebars de WireMock
+func wireMockHelper(key string) (string, bool) {
+	switch key {
+	case "request.method", "request.url", "request.path", "request.host", "request.clientIp":
+		return "{{" + key + "}}", true
+	case "request.rawBody":
+		return "{{request.body}}", true
+	case "request.receivedAt":
+		return "{{now}}", true
+	case "random.UUID":
+		return "{{randomValue type='UUID'}}", true
+	}
+
+	for _, prefix := range []string{"path.", "query.", "headers."} {
+		if strings.HasPrefix(key, prefix) {
+			return "{{request." + key + "}}", true
+		}
+	}
+	for _, prefix := range []string{"body.", "request.body."} {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			path := "$"
+			if !strings.HasPrefix(rest, "[") {
+				path += "."
+			}
+			return "{{jsonPath request.body '" + path + rest + "'}}", true
+		}
+	}
+	return "", false
+}
+
+// wireMockMatcher: valor exacto -> equalTo; regex de mocky ("^...") -> matches. mocky busca
+// la regex dentro del valor y WireMock exige que lo cubra completo.
+func wireMockMatcher(pattern string) WireMockMatcherDTO {
+	if regex, ok := strings.CutPrefix(pattern, "^"); ok {
+		if !strings.HasPrefix(regex, "^") || !strings.HasSuffix(regex, "$") {
+			regex = ".*(?:" + regex + ").*"
+		}
+		return WireMockMatcherDTO{"matches": regex}
+	}
+	return WireMockMatcherDTO{"equalTo": pattern}
+}
+
+// wireMockValuesMatcher exporta un matcher de valores repetibles; WireMock solo valida
+// presencia y valor, los conteos distintos de "al menos uno" se avisan con dropped
+func wireMockValuesMatcher(matcher entities.ValuesMatcherEntity, dropped func()) WireMockMatcherDTO {
+	if matcher.MaxCount != nil && *matcher.MaxCount == 0 || matcher.Count != nil && *matcher.Count == 0 {
+		return WireMockMatcherDTO{"absent": true}
+	}
+	if matcher.Count != nil || matcher.MaxCount != nil || matcher.MinCount != nil && *matcher.MinCount > 1 {
+		dropped()
+	}
+	if matcher.Pattern == "" {
+		return WireMockMatcherDTO{"matches": ".*"}
+	}
+	return wireMockMatcher(matcher.Pattern)
+}
+
+// queryValuesMatcher: el parámetro debe venir (con el valor indicado) o no venir
+func queryValuesMatcher(pattern string, absent bool) ValuesMatcherDTO {
+	if absent {
+		none := 0
+		return ValuesMatcherDTO{MaxCount: &none}
+	}
+	one := 1
+	return ValuesMatcherDTO{Pattern: pattern, MinCount: &one}
+}
+
+// exactPattern expresa un valor exacto; si empieza con "^" se escapa como regex para que
+// mocky no lo confunda con una
+func exactPattern(value string) string {
+	if strings.HasPrefix(value, "^") {
+		return matcherRegex(regexp.QuoteMeta(value))
+	}
+	return value
+}
+
+// pathTemplateFromRegex convierte "/users/[0-9]+/orders" en "/users/:param1/orders" con
+// path_params {"param1": "^^(?:[0-9]+)$"}. Falla si algún segmento no es una regex válida.
+func pathTemplateFromRegex(pattern string) (string, map[string]string, bool) {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	if !strings.HasPrefix(pattern, "/") {
+		return "", nil, false
+	}
+
+	segments, ok := splitRegexPath(pattern[1:])
+	if !ok {
+		return "", nil, false
+	}
+
+	var params map[string]string
+	for i, segment := range segments {
+		if literal, ok := regexLiteral(segment); ok {
+			segments[i] = literal
+			continue
+		}
+		if _, err := regexp.Compile(segment); err != nil {
+			return "", nil, false
+		}
+		if params == nil {
+			params = map[string]string{}
+		}
+		name := fmt.Sprintf("param%d", len(params)+1)
+		params[name] = matcherRegex(segment)
+		segments[i] = ":" + name
+	}
+	return "/" + strings.Join(segments, "/"), params, true
+}
+
+// splitRegexPath separa por "/" fuera de clases [...] y grupos (...); un grupo que cruza
+// segmentos ("(/v1)?") no tiene forma de path template
+func splitRegexPath(pattern string) ([]string, bool) {
+	var segments []string
+	var current strings.Builder
+	depth, class := 0, false
+
+	for i := 0; i < len(pattern); i++ {
+		char := pattern[i]
+		switch {
+		case char == '\\' && i+1 < len(pattern):
+			current.WriteByte(char)
+			i++
+			char = pattern[i]
+		case class:
+			class = char != ']'
+		case char == '[':
+			class = true
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+		case char == '/':
+			if depth > 0 {
+				return nil, false
+			}
+			segments = append(segments, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(char)
+	}
+	return append(segments, current.String()), depth == 0 && !class
+}
+
+// regexLiteral devuelve el texto de un segmento sin metacaracteres ("api\.v1" -> "api.v1")
+func regexLiteral(segment string) (string, bool) {
+	var literal strings.Builder
+	for i := 0; i < len(segment); i++ {
+		char := segment[i]
+		if char == '\\' && i+1 < len(segment) && strings.IndexByte(`.+*?()[]{}|^$\/-`, segment[i+1]) >= 0 {
+			literal.WriteByte(segment[i+1])
+			i++
+			continue
+		}
+		if strings.IndexByte(`.+*?()[]{}|^$\`, char) >= 0 {
+			return "", false
+		}
+		literal.WriteByte(char)
+	}
+	return literal.String(), true
+}
+
+// pathSegmentParam devuelve el nombre del path param de un segmento (:id o {id})
+func pathSegmentParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+		return segment[1:], true
+	}
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && len(segment) > 2 {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// partialJSONSchema exige los campos del JSON esperado y permite campos extra
+// (equalToJson con ignoreExtraElements)
+func partialJSONSchema(value any) map[string]any {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return map[string]any{"const": value}
+	}
+	properties := make(map[string]any, len(object))
+	required := make([]any, 0, len(object))
+	for _, key := range sortedKeys(object) {
+		properties[key] = partialJSONSchema(object[key])
+		required = append(required, key)
+	}
+	return map[string]any{"type": "object", "required": required, "properties": properties}
+}
+
+// jsonValue decodifica los valores que WireMock acepta como JSON dentro de un string
+func jsonValue(value any) any {
+	text, ok := value.(string)
+	if !ok {
+		return value
+	}
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return value
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+// mapStrings copia un valor JSON aplicando fn a cada string
+func mapStrings(node any, fn func(string) string) any {
+	switch typed := node.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, value := range typed {
+			out[key] = mapStrings(value, fn)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, value := range typed {
+			out[i] = mapStrings(value, fn)
+		}
+		return out
+	case string:
+		return fn(typed)
+	}
+	return node
+}
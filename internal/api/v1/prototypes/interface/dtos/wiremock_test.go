@@ -0,0 +1,90 @@
+package dtos
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// go test ./... -run Import -update regenera los .golden.json de testdata/import
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of testdata/import")
+
+// importResult es lo que se compara contra el .golden.json de cada fixture
+type importResult struct {
+	Prototypes []CreatePrototypeDTO `json:"prototypes"`
+	Warnings   []string             `json:"warnings"`
+}
+
+// checkImportFixture compara la traducción con su golden y revisa que cada prototype
+// pase las mismas reglas que POST /v1/prototypes
+func checkImportFixture(t *testing.T, name string, bundle ImportPrototypesDTO, warnings []string) {
+	t.Helper()
+
+	for i, prototype := range bundle.Prototypes {
+		if err := prototype.Validate(); err != nil {
+			t.Errorf("prototypes[%d] (%s) is invalid: %v", i, prototype.Name, err)
+		}
+	}
+
+	got, err := json.MarshalIndent(importResult{Prototypes: bundle.Prototypes, Warnings: warnings}, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "import", name+".golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s does not match %s:\n%s", name, golden, got)
+	}
+}
+
+func readImportFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "import", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestImportWireMock(t *testing.T) {
+	dto, err := DecodeWireMockMappingsDTO(readImportFixture(t, "wiremock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dto.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bundle, warnings := dto.ToImportPrototypesDTO("")
+	checkImportFixture(t, "wiremock", bundle, warnings)
+}
+
+func TestDecodeWireMockShapes(t *testing.T) {
+	for name, body := range map[string]string{
+		"single mapping": `{"request": {"method": "GET", "urlPath": "/a"}, "response": {"status": 200}}`,
+		"array":          `[{"request": {"method": "GET", "urlPath": "/a"}, "response": {"status": 200}}]`,
+		"mappings file":  `{"mappings": [{"request": {"method": "GET", "urlPath": "/a"}, "response": {"status": 200}}], "meta": {"total": 1}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dto, err := DecodeWireMockMappingsDTO([]byte(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(dto.Mappings) != 1 || dto.Mappings[0].Request.URLPath != "/a" {
+				t.Fatalf("mappings = %+v", dto.Mappings)
+			}
+		})
+	}
+}
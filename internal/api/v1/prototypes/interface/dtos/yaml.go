@@ -0,0 +1,48 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlToJSON convierte un documento YAML al JSON equivalente. yaml.v3 decodifica las
+// llaves que no son strings (códigos de respuesta como 200) a map[any]any y las fechas
+// sin comillas a time.Time; aquí las llaves vuelven a ser strings y las fechas conservan
+// su forma original.
+func yamlToJSON(body []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalizeYAML(doc))
+}
+
+func normalizeYAML(node any) any {
+	switch typed := node.(type) {
+	case map[string]any:
+		for key, value := range typed {
+			typed[key] = normalizeYAML(value)
+		}
+		return typed
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for key, value := range typed {
+			out[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return out
+	case []any:
+		for i, value := range typed {
+			typed[i] = normalizeYAML(value)
+		}
+		return typed
+	case time.Time:
+		if typed.Hour() == 0 && typed.Minute() == 0 && typed.Second() == 0 && typed.Nanosecond() == 0 {
+			return typed.Format("2006-01-02")
+		}
+		return typed.Format(time.RFC3339Nano)
+	}
+	return node
+}
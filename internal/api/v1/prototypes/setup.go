@@ -1,6 +1,7 @@
 package prototypes
 
 import (
+	"context"
 	"mocky/internal/api/v1/prototypes/app/services"
 	"mocky/internal/api/v1/prototypes/interface/controllers"
 	validator_controller "mocky/internal/context/controllers"
@@ -13,7 +14,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupPrototypesModule(r *gin.Engine) {
+func SetupPrototypesModule(r *gin.Engine, datasets placeholder.DatasetSource, partials placeholder.PartialSource, variables placeholder.VariableSource, formats validator_controller.CustomFormatSource) {
 
 	// repositories
 	// prototypesRepository := prototypes.NewPrototypesMongoRepository(
@@ -32,30 +33,58 @@ func SetupPrototypesModule(r *gin.Engine) {
 					Method:  m.Request.Method,
 					UrlPath: m.Request.UrlPath,
 				},
-				Name: m.Name,
+				Name:  m.Name,
+				Group: m.Group,
 				// … completa según tu struct
 			}
 		},
 		15*time.Minute,
 	)
 
+	// prototypeRevisionsRepository := prototypes.NewPrototypeRevisionsMongoRepository(
+	// 	settings.Settings.MONGO_DSN,
+	// 	"mocky_db",
+	// 	"prototype_revisions",
+	// )
+
+	prototypeRevisionsRepositoryInMemory := prototypes_inmemory.NewInMemoryPrototypeRevisionsRepository(24*time.Hour, 50)
+
 	// Validator
-	validator := validator_controller.NewValidator()
+	validator := validator_controller.NewValidator(formats)
 
 	// Placeholder
-	placeholderController := placeholder.NewPlaceholderController()
+	placeholderController := placeholder.NewPlaceholderController(datasets, partials, variables)
 
 	// Services
-	prototypesService := services.NewPrototypesService(prototypesRepositoryInMemory, validator, placeholderController)
+	prototypesService := services.NewPrototypesService(prototypesRepositoryInMemory, prototypeRevisionsRepositoryInMemory, validator, placeholderController)
+	prototypesService.SweepCompiled(context.Background(), time.Minute)
 
 	// Controllers
 	prototypesController := controllers.NewPrototypesController(prototypesService)
 
 	// Routes
 	prototypesGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/prototypes")
+	prototypesGroup.Use(controllers.AuthorMiddleware())
 	prototypesGroup.POST("", prototypesController.Create)
 	prototypesGroup.GET("", prototypesController.List)
+	prototypesGroup.POST("/import", prototypesController.Import)
+	prototypesGroup.POST("/import/openapi", prototypesController.ImportOpenAPI)
+	prototypesGroup.POST("/import/wiremock", prototypesController.ImportWireMock)
+	prototypesGroup.POST("/import/postman", prototypesController.ImportPostman)
+	prototypesGroup.POST("/import/har", prototypesController.ImportHAR)
+	prototypesGroup.GET("/export", prototypesController.Export)
+	prototypesGroup.GET("/export/wiremock", prototypesController.ExportWireMock)
+	prototypesGroup.GET("/openapi.yaml", prototypesController.OpenAPI)
+	prototypesGroup.GET("/openapi.json", prototypesController.OpenAPI)
 	prototypesGroup.GET("/:id", prototypesController.Retrieve)
+	prototypesGroup.PUT("/:id", prototypesController.Update)
+	prototypesGroup.PATCH("/:id", prototypesController.Patch)
+	prototypesGroup.DELETE("/:id", prototypesController.Delete)
+	prototypesGroup.GET("/:id/revisions", prototypesController.Revisions)
+	prototypesGroup.GET("/:id/revisions/diff", prototypesController.DiffRevisions)
+	prototypesGroup.GET("/:id/revisions/:rev", prototypesController.Revision)
+	prototypesGroup.POST("/:id/revisions/:rev/restore", prototypesController.RestoreRevision)
+	prototypesGroup.DELETE("", prototypesController.DeleteAll)
 
 	mockyGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/mocky")
 	mockyGroup.Any("/*path", prototypesController.Mock)
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+)
+
+func (s *VariablesService) Delete(cc *customctx.CustomContext, scope string) utils.Response[string] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Deleting variables")
+
+	if err := s.variablesRepository.DeleteByScope(cc, scope); err != nil {
+		entry.Error(err.Error())
+		code := http.StatusInternalServerError
+		if customErr, ok := err.(cerrs.CustomErrorInterface); ok {
+			code = customErr.GetCode()
+		}
+		return utils.Response[string]{
+			Error:      cerrs.NewCustomError(code, err.Error(), "variables.delete"),
+			StatusCode: code,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[string]{
+		Data:       scope,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// DeleteValue elimina una sola variable del scope
+func (s *VariablesService) DeleteValue(cc *customctx.CustomContext, scope string, key string) utils.Response[variables.VariableSetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Deleting variable %s.%s", scope, key)
+
+	existing := s.variablesRepository.GetByScope(cc, scope)
+	if existing.Err != nil {
+		return utils.Response[variables.VariableSetModel]{
+			Error:      existing.Err,
+			StatusCode: existing.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	if _, ok := existing.Data.Values[key]; !ok {
+		return utils.Response[variables.VariableSetModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusNotFound, "dont have variable "+key+" in scope "+scope, "variables.delete_value")),
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+		}
+	}
+
+	values := make(map[string]any, len(existing.Data.Values))
+	for k, v := range existing.Data.Values {
+		if k != key {
+			values[k] = v
+		}
+	}
+
+	return s._save(cc, variables.VariableSetModel{Scope: scope, Values: values})
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+)
+
+func (s *VariablesService) List(cc *customctx.CustomContext) utils.Response[variables.VariableSetListModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Listing variables")
+
+	variablesList := s.variablesRepository.FindAll(cc.Context())
+	if variablesList.Err != nil {
+		return utils.Response[variables.VariableSetListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Error:      variablesList.Err,
+			Success:    false,
+		}
+	}
+
+	return utils.Response[variables.VariableSetListModel]{
+		StatusCode: http.StatusOK,
+		Results:    variablesList.Data,
+		Success:    true,
+	}
+}
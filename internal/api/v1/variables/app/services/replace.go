@@ -0,0 +1,70 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"mocky/internal/api/v1/variables/domain/commands"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+)
+
+// Replace reemplaza todas las variables de un scope
+func (s *VariablesService) Replace(cc *customctx.CustomContext, command commands.ReplaceVariablesCommand) utils.Response[variables.VariableSetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Replacing variables of scope %s", command.Scope)
+
+	variableSet := command.ToEntity()
+
+	if err := _validateVariableName("scope", variableSet.Scope); err != nil {
+		return utils.Response[variables.VariableSetModel]{
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "variables.replace.validate")),
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+		}
+	}
+	for key := range variableSet.Values {
+		if err := _validateVariableName("key "+key, key); err != nil {
+			return utils.Response[variables.VariableSetModel]{
+				Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "variables.replace.validate")),
+				StatusCode: http.StatusUnprocessableEntity,
+				Success:    false,
+			}
+		}
+	}
+
+	return s._save(cc, variables.VariableSetModel{
+		Scope:  variableSet.Scope,
+		Values: variableSet.Values,
+	})
+}
+
+func (s *VariablesService) _save(cc *customctx.CustomContext, variableSetModel variables.VariableSetModel) utils.Response[variables.VariableSetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	if variableSetModel.Values == nil {
+		variableSetModel.Values = map[string]any{}
+	}
+
+	result := s.variablesRepository.SaveOrUpdate(cc, variableSetModel)
+	if result.Err != nil {
+		entry.Error(result.Err.Error())
+		return utils.Response[variables.VariableSetModel]{
+			Error:      result.Err,
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+		}
+	}
+
+	variableSetModel.ID = result.Data
+
+	return utils.Response[variables.VariableSetModel]{
+		Data:       variableSetModel,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
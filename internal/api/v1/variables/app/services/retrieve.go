@@ -0,0 +1,31 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+)
+
+func (s *VariablesService) Retrieve(cc *customctx.CustomContext, scope string) utils.Response[variables.VariableSetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving variables")
+
+	variableSet := s.variablesRepository.GetByScope(cc, scope)
+	if variableSet.Err != nil {
+		return utils.Response[variables.VariableSetModel]{
+			Error:      variableSet.Err,
+			StatusCode: variableSet.Err.GetCode(),
+			Success:    false,
+		}
+	}
+
+	return utils.Response[variables.VariableSetModel]{
+		StatusCode: http.StatusOK,
+		Data:       variableSet.Data,
+		Success:    true,
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"errors"
+	"mocky/internal/api/v1/variables/domain/repositories"
+	"regexp"
+)
+
+type VariablesService struct {
+	variablesRepository repositories.RepositoryVariables
+}
+
+func NewVariablesService(
+	variablesRepository repositories.RepositoryVariables,
+) *VariablesService {
+	return &VariablesService{
+		variablesRepository: variablesRepository,
+	}
+}
+
+// Scopes y llaves se usan en los templates ({{vars.base_url}}), por eso no admiten puntos
+var variableNameRe = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+func _validateVariableName(kind string, name string) error {
+	if !variableNameRe.MatchString(name) {
+		return errors.New(kind + " must contain only letters, numbers, '_' or '-'")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"mocky/internal/api/v1/variables/domain/commands"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+)
+
+// SetValue crea o actualiza una sola variable; el scope se crea si no existe
+func (s *VariablesService) SetValue(cc *customctx.CustomContext, command commands.SetVariableCommand) utils.Response[variables.VariableSetModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Setting variable %s.%s", command.Scope, command.Key)
+
+	for _, field := range [][2]string{{"scope", command.Scope}, {"key", command.Key}} {
+		if err := _validateVariableName(field[0], field[1]); err != nil {
+			return utils.Response[variables.VariableSetModel]{
+				Error:      cc.NewError(cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "variables.set_value.validate")),
+				StatusCode: http.StatusUnprocessableEntity,
+				Success:    false,
+			}
+		}
+	}
+
+	variableSetModel := variables.VariableSetModel{Scope: command.Scope, Values: map[string]any{}}
+	if existing := s.variablesRepository.GetByScope(cc, command.Scope); existing.Err == nil {
+		for key, value := range existing.Data.Values {
+			variableSetModel.Values[key] = value
+		}
+	}
+	variableSetModel.Values[command.Key] = command.Value
+
+	return s._save(cc, variableSetModel)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"context"
+	"mocky/internal/db/mongo/variables"
+)
+
+// Variables expone a los templates ({{vars.x}}) las variables globales,
+// sobrescritas por las del group del prototype.
+func (s *VariablesService) Variables(ctx context.Context, group string) map[string]any {
+	cc := customctx.NewCustomContext(ctx)
+
+	out := map[string]any{}
+	if global := s.variablesRepository.GetByScope(cc, variables.GlobalScope); global.Err == nil {
+		for key, value := range global.Data.Values {
+			out[key] = value
+		}
+	}
+	if group == "" || group == variables.GlobalScope {
+		return out
+	}
+	if scoped := s.variablesRepository.GetByScope(cc, group); scoped.Err == nil {
+		for key, value := range scoped.Data.Values {
+			out[key] = value
+		}
+	}
+	return out
+}
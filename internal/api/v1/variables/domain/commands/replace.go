@@ -0,0 +1,19 @@
+package commands
+
+import "mocky/internal/api/v1/variables/domain/entities"
+
+type ReplaceVariablesCommand struct {
+	Scope  string         `json:"scope" binding:"required"`
+	Values map[string]any `json:"values" binding:"required"`
+}
+
+func (c ReplaceVariablesCommand) Validate() error {
+	return nil
+}
+
+func (c ReplaceVariablesCommand) ToEntity() entities.VariableSetEntity {
+	return entities.VariableSetEntity{
+		Scope:  c.Scope,
+		Values: c.Values,
+	}
+}
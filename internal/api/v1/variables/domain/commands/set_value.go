@@ -0,0 +1,11 @@
+package commands
+
+type SetVariableCommand struct {
+	Scope string `json:"scope" binding:"required"`
+	Key   string `json:"key" binding:"required"`
+	Value any    `json:"value" binding:"required"`
+}
+
+func (c SetVariableCommand) Validate() error {
+	return nil
+}
@@ -0,0 +1,7 @@
+package entities
+
+// VariableSetEntity son las variables de un scope: "global" o el group de un prototype
+type VariableSetEntity struct {
+	Scope  string         `json:"scope" binding:"required"`
+	Values map[string]any `json:"values" binding:"required"`
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"context"
+	"mocky/internal/db/mongo/variables"
+)
+
+type RepositoryVariables interface {
+	Find(ctx context.Context, id string) utils.Result[variables.VariableSetModel]
+	FindAll(ctx context.Context) utils.Result[[]variables.VariableSetListModel]
+
+	GetByScope(cc *customctx.CustomContext, scope string) utils.Result[variables.VariableSetModel]
+	SaveOrUpdate(cc *customctx.CustomContext, document variables.VariableSetModel) utils.Result[string]
+	DeleteByScope(cc *customctx.CustomContext, scope string) error
+}
@@ -0,0 +1,13 @@
+package controllers
+
+import "mocky/internal/api/v1/variables/app/services"
+
+type VariablesController struct {
+	variablesService *services.VariablesService
+}
+
+func NewVariablesController(variablesService *services.VariablesService) *VariablesController {
+	return &VariablesController{
+		variablesService: variablesService,
+	}
+}
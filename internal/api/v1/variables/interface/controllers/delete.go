@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *VariablesController) Delete(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Deleting variables")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.variablesService.Delete(cc, ctx.Param("scope"))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *VariablesController) DeleteValue(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Deleting variable")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	response := c.variablesService.DeleteValue(cc, ctx.Param("scope"), ctx.Param("key"))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *VariablesController) List(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("List variables")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	variables := c.variablesService.List(cc)
+
+	ctx.JSON(variables.StatusCode, variables.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *VariablesController) Retrieve(ctx *gin.Context) {
+	entry := logger.FromContext(ctx.Request.Context())
+
+	entry.Info("Retrieving variables")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	variables := c.variablesService.Retrieve(cc, ctx.Param("scope"))
+
+	ctx.JSON(variables.StatusCode, variables.ToMapWithCustomContext(cc))
+}
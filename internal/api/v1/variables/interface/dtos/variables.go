@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"errors"
+	"mocky/internal/api/v1/variables/domain/commands"
+)
+
+type ReplaceVariablesDTO struct {
+	Values map[string]any `json:"values" binding:"required"`
+}
+
+func (dto ReplaceVariablesDTO) Validate() error {
+	return nil
+}
+
+func (dto ReplaceVariablesDTO) ToCommand(scope string) commands.ReplaceVariablesCommand {
+	return commands.ReplaceVariablesCommand{
+		Scope:  scope,
+		Values: dto.Values,
+	}
+}
+
+type SetVariableDTO struct {
+	Value any `json:"value"`
+}
+
+func (dto SetVariableDTO) Validate() error {
+
+	if dto.Value == nil {
+		return errors.New("value is required")
+	}
+
+	return nil
+}
+
+func (dto SetVariableDTO) ToCommand(scope string, key string) commands.SetVariableCommand {
+	return commands.SetVariableCommand{
+		Scope: scope,
+		Key:   key,
+		Value: dto.Value,
+	}
+}
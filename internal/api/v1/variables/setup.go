@@ -0,0 +1,42 @@
+package variables
+
+import (
+	"mocky/internal/api/v1/variables/app/services"
+	"mocky/internal/api/v1/variables/interface/controllers"
+	"mocky/internal/core/settings"
+	variables_inmemory "mocky/internal/db/inmemory/variables"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupVariablesModule registra las rutas de /v1/variables y devuelve el servicio
+// para que los templates de prototypes puedan leer {{vars.x}}.
+func SetupVariablesModule(r *gin.Engine) *services.VariablesService {
+
+	// repositories
+	// variablesRepository := variables.NewVariablesMongoRepository(
+	// 	settings.Settings.MONGO_DSN,
+	// 	"mocky_db",
+	// 	"variables",
+	// )
+
+	variablesRepositoryInMemory := variables_inmemory.NewInMemoryVariablesRepository(15 * time.Minute)
+
+	// Services
+	variablesService := services.NewVariablesService(variablesRepositoryInMemory)
+
+	// Controllers
+	variablesController := controllers.NewVariablesController(variablesService)
+
+	// Routes
+	variablesGroup := r.Group(settings.Settings.ROOT_PATH + "/v1/variables")
+	variablesGroup.GET("", variablesController.List)
+	variablesGroup.GET("/:scope", variablesController.Retrieve)
+	variablesGroup.PUT("/:scope", variablesController.Replace)
+	variablesGroup.DELETE("/:scope", variablesController.Delete)
+	variablesGroup.PUT("/:scope/:key", variablesController.SetValue)
+	variablesGroup.DELETE("/:scope/:key", variablesController.DeleteValue)
+
+	return variablesService
+}
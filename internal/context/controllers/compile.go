@@ -0,0 +1,176 @@
+package validator_controller
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	"mocky/internal/context/controllers/jsonschema"
+)
+
+// CompiledRequest es la forma lista para servir de las reglas del request de un prototype:
+// cada regex (headers, path params, matchers y schemas) y el JSON Schema se compilan una
+// vez, al registrarlo, y no en cada llamada al mock. Es inmutable y se comparte entre requests.
+type CompiledRequest struct {
+	patterns   map[string]*regexp.Regexp
+	registry   map[string]ValidationFunc
+	jsonSchema *jsonschema.Schema
+}
+
+// Compile prepara el request de un prototype; una regex o un JSON Schema inválido se
+// reporta con el lugar donde se declaró.
+func (v *ValidatorRequest) Compile(request entities.RequestEntity) (*CompiledRequest, error) {
+	c := &CompiledRequest{patterns: map[string]*regexp.Regexp{}}
+
+	// Headers y path params: exacto, o regex si empiezan con "^"
+	for _, group := range []struct {
+		name   string
+		values map[string]string
+	}{{"headers", request.Headers}, {"path_params", request.PathParams}} {
+		for _, key := range sortedNames(group.values) {
+			if err := c.addMatcherPattern(group.name+"."+key, group.values[key]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, group := range []struct {
+		name     string
+		matchers map[string]entities.ValuesMatcherEntity
+	}{{"header_matchers", request.HeaderMatchers}, {"query_matchers", request.QueryMatchers}} {
+		for _, key := range sortedNames(group.matchers) {
+			if err := c.addMatcherPattern(group.name+"."+key, group.matchers[key].Pattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Patterns de los schemas
+	if err := c.addBodySchemaPatterns("bodySchema", request.BodySchema); err != nil {
+		return nil, err
+	}
+	for _, group := range []struct {
+		name  string
+		props []entities.PropertyEntity
+	}{{"query_schema", request.QuerySchema}, {"header_schema", request.HeaderSchema}} {
+		for _, p := range group.props {
+			if err := c.addSchemaPatterns(joinPath(group.name, p.Name), p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if request.JSONSchema != nil {
+		compiled, err := jsonschema.Compile(request.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("jsonSchema: %w", err)
+		}
+		c.jsonSchema = compiled
+	}
+
+	c.registry = buildValidatorRegistry(v.formats, c.patterns)
+	return c, nil
+}
+
+// addMatcherPattern compila el valor de un header/path param/matcher que usa la convención "^regex"
+func (c *CompiledRequest) addMatcherPattern(where, schema string) error {
+	if !strings.HasPrefix(schema, "^") {
+		return nil
+	}
+	return c.addPattern(where, schema[1:])
+}
+
+func (c *CompiledRequest) addPattern(where, pattern string) error {
+	if _, ok := c.patterns[pattern]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("%s: invalid regex %q: %v", where, pattern, err)
+	}
+	c.patterns[pattern] = re
+	return nil
+}
+
+func (c *CompiledRequest) addBodySchemaPatterns(where string, schema *entities.BodySchemaEntity) error {
+	if schema == nil {
+		return nil
+	}
+	if err := c.addSchemaPatterns(where, schema.AsObjectProperty()); err != nil {
+		return err
+	}
+	if schema.Items != nil {
+		return c.addSchemaPatterns(where+".items", *schema.Items)
+	}
+	return nil
+}
+
+// addSchemaPatterns recorre propiedades, items y ramas de composición
+func (c *CompiledRequest) addSchemaPatterns(where string, p entities.PropertyEntity) error {
+	if p.Pattern != "" {
+		if err := c.addPattern(where+".pattern", p.Pattern); err != nil {
+			return err
+		}
+	}
+	for _, child := range p.Properties {
+		if err := c.addSchemaPatterns(joinPath(where, child.Name), child); err != nil {
+			return err
+		}
+	}
+	if p.Items != nil {
+		if err := c.addSchemaPatterns(where+".items", *p.Items); err != nil {
+			return err
+		}
+	}
+	keywords := []string{"one_of", "any_of", "all_of"}
+	for k, branches := range [][]entities.PropertyEntity{p.OneOf, p.AnyOf, p.AllOf} {
+		for i, branch := range branches {
+			if err := c.addSchemaPatterns(indexPath(where+"."+keywords[k], i), branch); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Matches compara el valor de un header/path param/matcher: exacto, o regex si el schema
+// empieza con "^". Una regex que no se compiló con el prototype no coincide.
+func (c *CompiledRequest) Matches(schema, value string) bool {
+	if !strings.HasPrefix(schema, "^") {
+		return value == schema
+	}
+	re, ok := c.patterns[schema[1:]]
+	if !ok {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// Validate valida el body con los patterns compilados
+func (c *CompiledRequest) Validate(schema entities.BodySchemaEntity, payload any) []ValidationError {
+	return ValidateAgainstSchema(schema, payload, c.registry)
+}
+
+// ValidateParams valida query params o headers con los patterns compilados
+func (c *CompiledRequest) ValidateParams(in string, props []entities.PropertyEntity, values map[string][]string) []ValidationError {
+	return validateParams(in, props, values, c.registry)
+}
+
+// ValidateJSONSchema valida el body contra el jsonSchema compilado, si lo hay
+func (c *CompiledRequest) ValidateJSONSchema(payload any) []ValidationError {
+	if c.jsonSchema == nil {
+		return nil
+	}
+	return jsonSchemaErrors(c.jsonSchema.Validate(payload))
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
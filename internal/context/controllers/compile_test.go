@@ -0,0 +1,64 @@
+package validator_controller
+
+import (
+	"testing"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
+
+func TestCompileRegistersNestedPatterns(t *testing.T) {
+	schema := entities.BodySchemaEntity{
+		Name: "test", TypeSchema: "object",
+		Properties: []entities.PropertyEntity{
+			{Name: "tags", Type: "array", Items: &entities.PropertyEntity{Type: "string", Pattern: "^[a-z]+$"}},
+			{Name: "code", Type: "string", OneOf: []entities.PropertyEntity{{Pattern: "^A"}, {Pattern: "^B"}}},
+			{Name: "id", Type: "string", AllOf: []entities.PropertyEntity{{Pattern: "^[0-9]+$"}}},
+		},
+		AllOf: []entities.PropertyEntity{{Properties: []entities.PropertyEntity{{Name: "ref", Type: "string", Pattern: "^r"}}}},
+	}
+
+	compiled, err := NewValidator(nil).Compile(entities.RequestEntity{BodySchema: &schema})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	for _, pattern := range []string{"^[a-z]+$", "^A", "^B", "^[0-9]+$", "^r"} {
+		if compiled.patterns[pattern] == nil {
+			t.Errorf("pattern %q was not compiled", pattern)
+		}
+	}
+
+	payload := map[string]any{"tags": []any{"ok", "NO"}, "code": "C", "id": "12", "ref": "x"}
+	got := errorCodes(compiled.Validate(schema, payload))
+	want := []string{CodeOneOf, CodePattern, CodePattern}
+	if len(got) != len(want) {
+		t.Fatalf("codes = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("codes = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCompileRejectsInvalidBranchPattern(t *testing.T) {
+	schema := entities.BodySchemaEntity{
+		Name: "test", TypeSchema: "object",
+		Properties: []entities.PropertyEntity{
+			{Name: "tags", Type: "array", Items: &entities.PropertyEntity{Type: "string", AnyOf: []entities.PropertyEntity{{Pattern: "("}}}},
+		},
+	}
+	if _, err := NewValidator(nil).Compile(entities.RequestEntity{BodySchema: &schema}); err == nil {
+		t.Fatal("expected an invalid regex error")
+	}
+}
+
+func TestUncompiledPatternIsASchemaError(t *testing.T) {
+	schema := entities.BodySchemaEntity{
+		Name: "test", TypeSchema: "object",
+		Properties: []entities.PropertyEntity{{Name: "code", Type: "string", Pattern: "^A"}},
+	}
+	got := errorCodes(ValidateAgainstSchema(schema, map[string]any{"code": "A"}, nil))
+	if len(got) != 1 || got[0] != CodeSchema {
+		t.Fatalf("codes = %v, want [%s]", got, CodeSchema)
+	}
+}
@@ -0,0 +1,338 @@
+package validator_controller
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
+
+// ======== one_of / any_of / all_of ========
+
+// resolveComposition picks the branches that apply to the value and merges them
+// into the property, so the result is validated once (paths and
+// additional_properties see the fields of the chosen branch). mergeProperty
+// intersects the rules; what can't be intersected is checked by checkRemainder.
+func (e engine) resolveComposition(p entities.PropertyEntity, raw any, path string, additional bool, errs *[]ValidationError) (entities.PropertyEntity, bool) {
+	effective := p
+	effective.OneOf, effective.AnyOf, effective.AllOf, effective.Discriminator = nil, nil, nil, nil
+
+	// all_of: every branch applies
+	for _, branch := range p.AllOf {
+		effective = mergeProperty(effective, branch)
+	}
+	for _, branch := range p.AllOf {
+		e.checkRemainder(effective, branch, raw, path, errs)
+	}
+
+	if p.Discriminator != nil {
+		branch, ok := selectByDiscriminator(p, raw, path, errs)
+		if !ok {
+			return effective, false
+		}
+		merged := mergeProperty(effective, branch)
+		e.checkRemainder(merged, branch, raw, path, errs)
+		return merged, true
+	}
+
+	if len(p.OneOf) > 0 {
+		var matched []int
+		for i, branch := range p.OneOf {
+			if e.matchesBranch(effective, branch, raw, additional) {
+				matched = append(matched, i)
+			}
+		}
+		switch len(matched) {
+		case 0:
+			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("value does not match any schema in one_of (%s)", branchNames(p.OneOf, nil)), Code: CodeOneOf})
+			return effective, false
+		case 1:
+			effective = mergeProperty(effective, p.OneOf[matched[0]])
+		default:
+			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("value matches more than one schema in one_of (%s)", branchNames(p.OneOf, matched)), Code: CodeOneOf})
+			return effective, false
+		}
+	}
+
+	if len(p.AnyOf) > 0 {
+		base := effective
+		matched := 0
+		for _, branch := range p.AnyOf {
+			if e.matchesBranch(base, branch, raw, additional) {
+				effective = mergeProperty(effective, branch)
+				matched++
+			}
+		}
+		if matched == 0 {
+			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("value does not match any schema in any_of (%s)", branchNames(p.AnyOf, nil)), Code: CodeAnyOf})
+			return effective, false
+		}
+	}
+
+	return effective, true
+}
+
+// matchesBranch reports whether the value satisfies base and branch together
+func (e engine) matchesBranch(base, branch entities.PropertyEntity, raw any, additional bool) bool {
+	merged := mergeProperty(base, branch)
+	if !e.matches(merged, raw, additional) {
+		return false
+	}
+	var errs []ValidationError
+	e.checkRemainder(merged, branch, raw, "", &errs)
+	return len(errs) == 0
+}
+
+// checkRemainder validates the rules of branch that didn't fit in merged: pattern, format,
+// multiple_of, enum and const hold a single value, so when the base (or an earlier branch)
+// already declares a different one the branch's is checked here on its own.
+func (e engine) checkRemainder(merged, branch entities.PropertyEntity, raw any, path string, errs *[]ValidationError) {
+	rest, ok := remainder(merged, branch)
+	if !ok || raw == nil {
+		return
+	}
+	validator, exists := e.registry[strings.ToLower(rest.Type)]
+	if !exists {
+		return
+	}
+
+	var restErrs []ValidationError
+	if valid, _ := validator(rest, raw, path, true, &restErrs); valid {
+		validateEnumConst(rest, raw, path, &restErrs)
+	}
+	for _, err := range restErrs {
+		// the type error is already reported by the validation of merged
+		if err.Code == CodeType && strings.EqualFold(rest.Type, merged.Type) {
+			continue
+		}
+		*errs = append(*errs, err)
+	}
+}
+
+// remainder returns the single-valued rules of branch that merged doesn't carry
+func remainder(merged, branch entities.PropertyEntity) (entities.PropertyEntity, bool) {
+	rest := entities.PropertyEntity{Name: branch.Name, Type: merged.Type}
+	if branch.Type != "" && !sameType(merged.Type, branch.Type) {
+		rest.Type = branch.Type
+	}
+	found := rest.Type != merged.Type
+	if branch.Pattern != "" && branch.Pattern != merged.Pattern {
+		rest.Pattern, found = branch.Pattern, true
+	}
+	if branch.Format != "" && !strings.EqualFold(branch.Format, merged.Format) {
+		rest.Format, found = branch.Format, true
+	}
+	if branch.MultipleOf != nil && (merged.MultipleOf == nil || *merged.MultipleOf != *branch.MultipleOf) {
+		rest.MultipleOf, found = branch.MultipleOf, true
+	}
+	if len(branch.Enum) > 0 && canonicalJSON(branch.Enum) != canonicalJSON(merged.Enum) {
+		rest.Enum, found = branch.Enum, true
+	}
+	if branch.Const != nil && canonicalJSON(branch.Const) != canonicalJSON(merged.Const) {
+		rest.Const, found = branch.Const, true
+	}
+	return rest, found
+}
+
+// sameType: integer is a number, so merging both keeps integer without a remainder
+func sameType(merged, branch string) bool {
+	merged, branch = strings.ToLower(merged), strings.ToLower(branch)
+	return merged == branch || (merged == "integer" && branch == "number")
+}
+
+// selectByDiscriminator reads the discriminator field and returns the branch it names
+func selectByDiscriminator(p entities.PropertyEntity, raw any, path string, errs *[]ValidationError) (entities.PropertyEntity, bool) {
+	field := p.Discriminator.PropertyName
+	branches := append(append([]entities.PropertyEntity{}, p.OneOf...), p.AnyOf...)
+
+	m, ok := raw.(map[string]any)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected object, got %T", raw), Code: CodeType})
+		return entities.PropertyEntity{}, false
+	}
+	value, ok := m[field].(string)
+	if !ok {
+		if _, exists := m[field]; !exists {
+			*errs = append(*errs, ValidationError{Path: joinPath(path, field), Err: "missing discriminator field", Code: CodeDiscriminator})
+		} else {
+			*errs = append(*errs, ValidationError{Path: joinPath(path, field), Err: fmt.Sprintf("expected string, got %T", m[field]), Code: CodeType})
+		}
+		return entities.PropertyEntity{}, false
+	}
+
+	name := value
+	if mapped, isMapped := p.Discriminator.Mapping[value]; isMapped {
+		name = mapped
+	}
+	for _, branch := range branches {
+		if branch.Name == name {
+			return branch, true
+		}
+	}
+
+	*errs = append(*errs, ValidationError{Path: joinPath(path, field), Err: fmt.Sprintf("value must be one of [%s]", strings.Join(discriminatorValues(p.Discriminator, branches), ", ")), Code: CodeDiscriminator})
+	return entities.PropertyEntity{}, false
+}
+
+func discriminatorValues(d *entities.DiscriminatorEntity, branches []entities.PropertyEntity) []string {
+	if len(d.Mapping) > 0 {
+		values := make([]string, 0, len(d.Mapping))
+		for value := range d.Mapping {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		return values
+	}
+	values := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		values = append(values, branch.Name)
+	}
+	return values
+}
+
+// branchNames describes the branches (all, or only the given indexes) for error messages
+func branchNames(branches []entities.PropertyEntity, only []int) string {
+	var names []string
+	describe := func(i int) {
+		if branches[i].Name != "" {
+			names = append(names, branches[i].Name)
+			return
+		}
+		names = append(names, fmt.Sprintf("#%d", i))
+	}
+	if only == nil {
+		for i := range branches {
+			describe(i)
+		}
+	} else {
+		for _, i := range only {
+			describe(i)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// mergeProperty intersects a branch with the base property: the value has to satisfy
+// both, so bounds keep the tightest limit, unique_items and additional_properties: false
+// win, and a field (or items) declared on both sides keeps the two definitions as all_of.
+// Single-valued rules (pattern, format, multiple_of, enum, const) keep the base's; the
+// branch's are checked by checkRemainder.
+func mergeProperty(base, branch entities.PropertyEntity) entities.PropertyEntity {
+	merged := base
+	merged.Properties = append([]entities.PropertyEntity{}, base.Properties...)
+
+	if merged.Type == "" || (strings.EqualFold(merged.Type, "number") && strings.EqualFold(branch.Type, "integer")) {
+		merged.Type = branch.Type
+	}
+	for _, prop := range branch.Properties {
+		combined := false
+		for i := range merged.Properties {
+			if merged.Properties[i].Name == prop.Name {
+				merged.Properties[i] = combineProperty(merged.Properties[i], prop)
+				combined = true
+				break
+			}
+		}
+		if !combined {
+			merged.Properties = append(merged.Properties, prop)
+		}
+	}
+	switch {
+	case merged.Items == nil:
+		merged.Items = branch.Items
+	case branch.Items != nil:
+		items := combineProperty(*merged.Items, *branch.Items)
+		merged.Items = &items
+	}
+
+	merged.MinLength = max(merged.MinLength, branch.MinLength)
+	merged.MaxLength = minLimit(merged.MaxLength, branch.MaxLength)
+	merged.MinItems = max(merged.MinItems, branch.MinItems)
+	merged.MaxItems = minLimit(merged.MaxItems, branch.MaxItems)
+	merged.UniqueItems = merged.UniqueItems || branch.UniqueItems
+	merged.Minimum = tighter(merged.Minimum, branch.Minimum, math.Max)
+	merged.ExclusiveMinimum = tighter(merged.ExclusiveMinimum, branch.ExclusiveMinimum, math.Max)
+	merged.Maximum = tighter(merged.Maximum, branch.Maximum, math.Min)
+	merged.ExclusiveMaximum = tighter(merged.ExclusiveMaximum, branch.ExclusiveMaximum, math.Min)
+
+	if merged.Format == "" {
+		merged.Format = branch.Format
+	}
+	if merged.Pattern == "" {
+		merged.Pattern = branch.Pattern
+	}
+	if merged.MultipleOf == nil {
+		merged.MultipleOf = branch.MultipleOf
+	}
+	if len(merged.Enum) == 0 {
+		merged.Enum = branch.Enum
+	}
+	if merged.Const == nil {
+		merged.Const = branch.Const
+	}
+	if branch.AdditionalProperties != nil && (merged.AdditionalProperties == nil || !*branch.AdditionalProperties) {
+		merged.AdditionalProperties = branch.AdditionalProperties
+	}
+
+	// Nested composition inside a branch is resolved with the merged result
+	merged.OneOf = append(merged.OneOf, branch.OneOf...)
+	merged.AnyOf = append(merged.AnyOf, branch.AnyOf...)
+	merged.AllOf = append(merged.AllOf, branch.AllOf...)
+	if branch.Discriminator != nil {
+		merged.Discriminator = branch.Discriminator
+	}
+	return merged
+}
+
+// combineProperty joins two definitions of the same field: the second one becomes an
+// all_of branch of the first, so both are validated against the value
+func combineProperty(first, second entities.PropertyEntity) entities.PropertyEntity {
+	combined := first
+	combined.AllOf = append(append([]entities.PropertyEntity{}, first.AllOf...), second)
+	combined.IsRequired = first.IsRequired || second.IsRequired
+	combined.Nullable = first.Nullable && second.Nullable
+	if combined.Default == nil {
+		combined.Default = second.Default
+	}
+	return combined
+}
+
+// minLimit is the smallest non-zero limit (0 means "no limit")
+func minLimit(a, b int32) int32 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// tighter picks the limit with pick (math.Max for minimums, math.Min for maximums)
+func tighter(a, b *float64, pick func(x, y float64) float64) *float64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	v := pick(*a, *b)
+	return &v
+}
+
+func deepCopy(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, item := range x {
+			out[k] = deepCopy(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(x))
+		for i, item := range x {
+			out[i] = deepCopy(item)
+		}
+		return out
+	}
+	return v
+}
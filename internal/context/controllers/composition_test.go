@@ -0,0 +1,223 @@
+package validator_controller
+
+import (
+	"sort"
+	"testing"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
+
+func float(v float64) *float64 { return &v }
+
+func boolean(v bool) *bool { return &v }
+
+// compiledBody compiles an object bodySchema holding the given properties
+func compiledBody(t *testing.T, props ...entities.PropertyEntity) (*CompiledRequest, entities.BodySchemaEntity) {
+	t.Helper()
+	schema := entities.BodySchemaEntity{Name: "test", TypeSchema: "object", AditionalProperties: true, Properties: props}
+	compiled, err := NewValidator(nil).Compile(entities.RequestEntity{BodySchema: &schema})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return compiled, schema
+}
+
+func errorCodes(errs []ValidationError) []string {
+	codes := make([]string, 0, len(errs))
+	for _, err := range errs {
+		codes = append(codes, err.Code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestAllOfIntersectsBranches(t *testing.T) {
+	age := entities.PropertyEntity{
+		Name: "age", Type: "object",
+		AllOf: []entities.PropertyEntity{
+			{Properties: []entities.PropertyEntity{{Name: "years", Type: "integer", Minimum: float(18)}}},
+			{Properties: []entities.PropertyEntity{{Name: "years", Type: "integer", Maximum: float(65), IsRequired: true}}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		prop  entities.PropertyEntity
+		value any
+		codes []string
+	}{
+		{
+			name:  "branch minimum does not loosen the base",
+			prop:  entities.PropertyEntity{Name: "n", Type: "integer", Minimum: float(10), AllOf: []entities.PropertyEntity{{Minimum: float(0)}}},
+			value: 3.0,
+			codes: []string{CodeMinimum},
+		},
+		{
+			name:  "base and branch minimum both hold",
+			prop:  entities.PropertyEntity{Name: "n", Type: "integer", Minimum: float(10), AllOf: []entities.PropertyEntity{{Minimum: float(0)}}},
+			value: 12.0,
+		},
+		{
+			name:  "tightest maximum wins",
+			prop:  entities.PropertyEntity{Name: "n", Type: "number", AllOf: []entities.PropertyEntity{{Maximum: float(5)}, {Maximum: float(50)}}},
+			value: 20.0,
+			codes: []string{CodeMaximum},
+		},
+		{
+			name:  "exclusive bounds from different branches",
+			prop:  entities.PropertyEntity{Name: "n", Type: "number", AllOf: []entities.PropertyEntity{{ExclusiveMinimum: float(1)}, {ExclusiveMaximum: float(3)}}},
+			value: 3.0,
+			codes: []string{CodeExclusiveMaximum},
+		},
+		{
+			name:  "tightest max_length wins",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", MaxLength: 10, AllOf: []entities.PropertyEntity{{MaxLength: 5}}},
+			value: "abcdefg",
+			codes: []string{CodeMaxLength},
+		},
+		{
+			name:  "largest min_length wins",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", MinLength: 2, AllOf: []entities.PropertyEntity{{MinLength: 1}}},
+			value: "a",
+			codes: []string{CodeMinLength},
+		},
+		{
+			name:  "every pattern has to match",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", AllOf: []entities.PropertyEntity{{Pattern: "^a"}, {Pattern: "b$"}}},
+			value: "ac",
+			codes: []string{CodePattern},
+		},
+		{
+			name:  "base pattern is kept next to the branch pattern",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", Pattern: "^[0-9]+$", AllOf: []entities.PropertyEntity{{Pattern: "^1"}}},
+			value: "1a",
+			codes: []string{CodePattern},
+		},
+		{
+			name:  "patterns of every branch match",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", AllOf: []entities.PropertyEntity{{Pattern: "^a"}, {Pattern: "b$"}}},
+			value: "ab",
+		},
+		{
+			name:  "value has to be in every enum",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", Enum: []any{"a", "b"}, AllOf: []entities.PropertyEntity{{Enum: []any{"b", "c"}}}},
+			value: "a",
+			codes: []string{CodeEnum},
+		},
+		{
+			name:  "value in the intersection of the enums",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", Enum: []any{"a", "b"}, AllOf: []entities.PropertyEntity{{Enum: []any{"b", "c"}}}},
+			value: "b",
+		},
+		{
+			name:  "every multiple_of holds",
+			prop:  entities.PropertyEntity{Name: "n", Type: "integer", MultipleOf: float(2), AllOf: []entities.PropertyEntity{{MultipleOf: float(3)}}},
+			value: 4.0,
+			codes: []string{CodeMultipleOf},
+		},
+		{
+			name:  "same field in two branches keeps both minimum and maximum",
+			prop:  age,
+			value: map[string]any{"years": 70.0},
+			codes: []string{CodeMaximum},
+		},
+		{
+			name:  "same field in two branches keeps the minimum of the first",
+			prop:  age,
+			value: map[string]any{"years": 10.0},
+			codes: []string{CodeMinimum},
+		},
+		{
+			name:  "same field is required if any branch requires it",
+			prop:  age,
+			value: map[string]any{},
+			codes: []string{CodeRequired},
+		},
+		{
+			name:  "same field within every branch",
+			prop:  age,
+			value: map[string]any{"years": 30.0},
+		},
+		{
+			name: "additional_properties false of a branch wins",
+			prop: entities.PropertyEntity{Name: "o", Type: "object", AdditionalProperties: boolean(true), AllOf: []entities.PropertyEntity{
+				{AdditionalProperties: boolean(false), Properties: []entities.PropertyEntity{{Name: "a", Type: "string"}}},
+			}},
+			value: map[string]any{"a": "x", "b": "y"},
+			codes: []string{CodeAdditionalProperties},
+		},
+		{
+			name:  "one_of branch keeps the pattern of the base",
+			prop:  entities.PropertyEntity{Name: "s", Type: "string", Pattern: "^[a-z]+$", OneOf: []entities.PropertyEntity{{Pattern: "^x"}, {MinLength: 20}}},
+			value: "x1",
+			codes: []string{CodeOneOf},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, schema := compiledBody(t, tt.prop)
+			errs := compiled.Validate(schema, map[string]any{tt.prop.Name: tt.value})
+			got := errorCodes(errs)
+			if len(got) != len(tt.codes) {
+				t.Fatalf("codes = %v, want %v (%v)", got, tt.codes, errs)
+			}
+			want := append([]string{}, tt.codes...)
+			sort.Strings(want)
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("codes = %v, want %v (%v)", got, want, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestSampleValueSatisfiesAllOf(t *testing.T) {
+	tests := []struct {
+		name string
+		prop entities.PropertyEntity
+	}{
+		{
+			name: "minimum of the base and a looser branch",
+			prop: entities.PropertyEntity{Name: "n", Type: "integer", Minimum: float(10), AllOf: []entities.PropertyEntity{{Minimum: float(0)}}},
+		},
+		{
+			name: "bounds from different branches",
+			prop: entities.PropertyEntity{Name: "n", Type: "number", AllOf: []entities.PropertyEntity{{Minimum: float(5)}, {Maximum: float(7)}}},
+		},
+		{
+			name: "multiple_of from the base and a branch",
+			prop: entities.PropertyEntity{Name: "n", Type: "integer", MultipleOf: float(2), AllOf: []entities.PropertyEntity{{MultipleOf: float(3)}}},
+		},
+		{
+			name: "lengths from different branches",
+			prop: entities.PropertyEntity{Name: "s", Type: "string", AllOf: []entities.PropertyEntity{{MinLength: 8}, {MaxLength: 9}}},
+		},
+		{
+			name: "patterns from the base and a branch",
+			prop: entities.PropertyEntity{Name: "s", Type: "string", Pattern: "^[0-9]+$", AllOf: []entities.PropertyEntity{{Pattern: "^[0-9]{4}$"}}},
+		},
+		{
+			name: "enums from the base and a branch",
+			prop: entities.PropertyEntity{Name: "s", Type: "string", Enum: []any{"a", "b"}, AllOf: []entities.PropertyEntity{{Enum: []any{"b", "c"}}}},
+		},
+		{
+			name: "same field constrained by two branches",
+			prop: entities.PropertyEntity{Name: "o", Type: "object", AllOf: []entities.PropertyEntity{
+				{Properties: []entities.PropertyEntity{{Name: "years", Type: "integer", Minimum: float(18)}}},
+				{Properties: []entities.PropertyEntity{{Name: "years", Type: "integer", Maximum: float(65), IsRequired: true}}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, schema := compiledBody(t, tt.prop)
+			sample := SampleBody(&schema)
+			if errs := compiled.Validate(schema, sample); len(errs) > 0 {
+				t.Fatalf("sample %v breaks the schema: %v", sample, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package validator_controller
+
+import (
+	"fmt"
+	"regexp"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+	"mocky/internal/context/controllers/jsonschema"
+)
+
+// CompiledContract es el schema de respuesta compilado de un prototype (response.schema
+// y/o response.jsonSchema). Como CompiledRequest, se arma una vez y se comparte.
+type CompiledContract struct {
+	rules  *CompiledRequest
+	schema *entities.BodySchemaEntity
+}
+
+// CompileContract prepara el contrato de la respuesta; nil si el prototype no declara ninguno.
+func (v *ValidatorRequest) CompileContract(response entities.ResponseEntity) (*CompiledContract, error) {
+	if !response.HasContract() {
+		return nil, nil
+	}
+
+	rules := &CompiledRequest{patterns: map[string]*regexp.Regexp{}}
+	if response.Schema != nil && response.Schema.TypeSchema != "" {
+		if err := rules.addBodySchemaPatterns("response.schema", response.Schema); err != nil {
+			return nil, err
+		}
+	}
+
+	if response.JSONSchema != nil {
+		compiled, err := jsonschema.Compile(response.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("response.jsonSchema: %w", err)
+		}
+		rules.jsonSchema = compiled
+	}
+
+	rules.registry = buildValidatorRegistry(v.formats, rules.patterns)
+
+	contract := &CompiledContract{rules: rules}
+	if response.Schema != nil && response.Schema.TypeSchema != "" {
+		contract.schema = response.Schema
+	}
+	return contract, nil
+}
+
+// Validate revisa el body ya renderizado contra el contrato. Los errores se reportan con
+// In = "response"; los default del schema no se escriben en el body.
+func (c *CompiledContract) Validate(body any) []ValidationError {
+	if c == nil {
+		return nil
+	}
+
+	var errs []ValidationError
+	if c.schema != nil {
+		errs = append(errs, c.rules.Validate(*c.schema, deepCopy(body))...)
+	}
+	errs = append(errs, c.rules.ValidateJSONSchema(body)...)
+
+	for i := range errs {
+		errs[i].In = InResponse
+	}
+	return errs
+}
@@ -1,23 +1,63 @@
 package validator_controller
 
-import "mocky/internal/api/v1/prototypes/domain/entities"
+import (
+	"strings"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
 
 type ValidatorRequest struct {
-	registry map[string]ValidationFunc
+	formats CustomFormatSource
 }
 
-func NewValidator() *ValidatorRequest {
+func NewValidator(formats CustomFormatSource) *ValidatorRequest {
 	return &ValidatorRequest{
-		registry: buildValidatorRegistry(),
+		formats: formats,
 	}
 }
 
-func (v *ValidatorRequest) Validate(schema entities.BodySchemaEntity, payload map[string]any) []ValidationError {
+// UnknownFormats lists the formats used by the request schemas (body, query and
+// headers) and the response schema that are neither built-in nor registered, so
+// the prototype can be rejected instead of ignoring them.
+func (v *ValidatorRequest) UnknownFormats(request entities.RequestEntity, response entities.ResponseEntity) []string {
+	var unknown []string
+
+	var walk func(p entities.PropertyEntity)
+	walkAll := func(props []entities.PropertyEntity) {
+		for _, p := range props {
+			walk(p)
+		}
+	}
+	walk = func(p entities.PropertyEntity) {
+		if p.Format != "" && !IsBuiltinFormat(p.Format) {
+			known := false
+			if v.formats != nil {
+				_, known = v.formats.CustomFormat(strings.ToLower(p.Format))
+			}
+			if !known {
+				unknown = append(unknown, p.Format)
+			}
+		}
+		walkAll(p.Properties)
+		if p.Items != nil {
+			walk(*p.Items)
+		}
+		walkAll(p.OneOf)
+		walkAll(p.AnyOf)
+		walkAll(p.AllOf)
+	}
 
-	errs := ValidateAgainstSchema(schema, payload, v.registry)
-	if len(errs) > 0 {
-		return errs
+	for _, schema := range []*entities.BodySchemaEntity{request.BodySchema, response.Schema} {
+		if schema == nil {
+			continue
+		}
+		walk(schema.AsObjectProperty())
+		if schema.Items != nil {
+			walk(*schema.Items)
+		}
 	}
+	walkAll(request.QuerySchema)
+	walkAll(request.HeaderSchema)
 
-	return nil
+	return unknown
 }
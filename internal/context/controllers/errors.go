@@ -1,10 +1,46 @@
 package validator_controller
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Códigos estables de ValidationError.Code (los de JSON Schema usan el keyword en snake_case)
+const (
+	CodeRequired             = "required"
+	CodeType                 = "type"
+	CodePattern              = "pattern"
+	CodeFormat               = "format"
+	CodeMinLength            = "min_length"
+	CodeMaxLength            = "max_length"
+	CodeMinimum              = "minimum"
+	CodeMaximum              = "maximum"
+	CodeExclusiveMinimum     = "exclusive_minimum"
+	CodeExclusiveMaximum     = "exclusive_maximum"
+	CodeMultipleOf           = "multiple_of"
+	CodeEnum                 = "enum"
+	CodeConst                = "const"
+	CodeAdditionalProperties = "additional_properties"
+	CodeMinItems             = "min_items"
+	CodeMaxItems             = "max_items"
+	CodeUniqueItems          = "unique_items"
+	CodeOneOf                = "one_of"
+	CodeAnyOf                = "any_of"
+	CodeDiscriminator        = "discriminator"
+	CodeSingleValue          = "single_value"
+	CodeCount                = "count"  // cantidad exacta de valores de un matcher
+	CodeSchema               = "schema" // el schema del prototype es inválido
+)
 
 type ValidationError struct {
-	Path string
+	Path string // forma con puntos: items[0].sku
 	Err  string
+	Code string
+	In   string // body (default), query, header o path
+	// Pointer es el JSON pointer del valor (/items/0/sku); si está vacío se deriva de Path
+	Pointer string
 }
 
 func (e ValidationError) String() string {
@@ -13,3 +49,77 @@ func (e ValidationError) String() string {
 	}
 	return fmt.Sprintf("%s: %s", e.Path, e.Err)
 }
+
+// Location devuelve dónde está el valor inválido: body, query o header
+func (e ValidationError) Location() string {
+	if e.In == "" {
+		return InBody
+	}
+	return e.In
+}
+
+// JSONPointer devuelve el RFC 6901 pointer del valor inválido, relativo a su Location
+func (e ValidationError) JSONPointer() string {
+	if e.Pointer != "" {
+		return e.Pointer
+	}
+	return toPointer(e.Path)
+}
+
+// toPointer convierte "items[0].sku" en "/items/0/sku"; los nombres entre corchetes y
+// comillas (filter["a.b"], ver joinPath) son un solo segmento
+func toPointer(path string) string {
+	var b strings.Builder
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '.':
+			i++
+		case strings.HasPrefix(path[i:], `["`):
+			quoted, err := strconv.QuotedPrefix(path[i+1:])
+			if err != nil {
+				return b.String()
+			}
+			name, _ := strconv.Unquote(quoted)
+			b.WriteString("/" + escape.Replace(name))
+			i += 1 + len(quoted) + 1
+		case path[i] == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return b.String()
+			}
+			if index := path[i+1 : i+end]; isIndex(index) {
+				b.WriteString("/" + index)
+			}
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			b.WriteString("/" + escape.Replace(path[i:i+end]))
+			i += end
+		}
+	}
+	return b.String()
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// keywordCode convierte un keyword de JSON Schema (minLength) en código (min_length)
+func keywordCode(keyword string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimPrefix(keyword, "$") {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
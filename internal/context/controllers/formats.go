@@ -0,0 +1,163 @@
+package validator_controller
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mocky/internal/context/controllers/jsonschema"
+)
+
+// FormatChecker reports whether a string satisfies a format
+type FormatChecker func(s string) bool
+
+// CustomFormatSource gives access to the formats registered through /v1/formats
+type CustomFormatSource interface {
+	CustomFormat(name string) (FormatChecker, bool)
+}
+
+// ======== Built-in formats ========
+
+// Aliases accepted for the built-in formats
+var formatAliases = map[string]string{
+	"phone":    "e164",
+	"e.164":    "e164",
+	"datetime": "date-time",
+	"url":      "uri",
+}
+
+func builtinFormatName(name string) string {
+	name = strings.ToLower(name)
+	if alias, ok := formatAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// IsBuiltinFormat reports whether name is one of the formats shipped with mocky
+func IsBuiltinFormat(name string) bool {
+	return jsonschema.IsKnownFormat(builtinFormatName(name))
+}
+
+// checkFormat validates s against a built-in format or, if it isn't one, a custom format.
+// registered is false when the format is neither: a custom format deleted (or expired)
+// after the prototype was created must fail instead of letting every value through.
+func checkFormat(name string, s string, custom CustomFormatSource) (valid bool, registered bool) {
+	if known, ok := jsonschema.CheckFormat(builtinFormatName(name), s); known {
+		return ok, true
+	}
+	if custom != nil {
+		if checker, ok := custom.CustomFormat(strings.ToLower(name)); ok {
+			return checker(s), true
+		}
+	}
+	return false, false
+}
+
+// ======== Custom formats ========
+
+// checksumRules are the rules a custom format can be backed by
+var checksumRules = map[string]FormatChecker{
+	"luhn":  isLuhn,
+	"mod97": isMod97,
+	"clabe": isCLABE,
+}
+
+// CompileFormat builds the checker of a custom format. Both pattern and checksum
+// (when present) must pass.
+func CompileFormat(pattern string, checksum string) (FormatChecker, error) {
+	if pattern == "" && checksum == "" {
+		return nil, errors.New("pattern or checksum is required")
+	}
+
+	var re *regexp.Regexp
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		re = compiled
+	}
+
+	var rule FormatChecker
+	if checksum != "" {
+		known, ok := checksumRules[strings.ToLower(checksum)]
+		if !ok {
+			return nil, fmt.Errorf("unknown checksum %q (expected luhn, mod97 or clabe)", checksum)
+		}
+		rule = known
+	}
+
+	return func(s string) bool {
+		if re != nil && !re.MatchString(s) {
+			return false
+		}
+		return rule == nil || rule(s)
+	}, nil
+}
+
+// isLuhn validates credit cards and other identifiers with a Luhn check digit
+func isLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isMod97 validates an IBAN (ISO 13616): the first 4 characters move to the end and the resulting number mod 97 == 1
+func isMod97(s string) bool {
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(s) < 5 {
+		return false
+	}
+	rearranged := s[4:] + s[:4]
+	remainder := 0
+	for _, c := range rearranged {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			v := int(c-'A') + 10
+			remainder = (remainder*100 + v) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// isCLABE validates a Mexican interbank CLABE (18 digits, weights 3-7-1)
+func isCLABE(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+	weights := []int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		sum += (int(c-'0') * weights[i%3]) % 10
+	}
+	check := (10 - sum%10) % 10
+	return s[17] == byte('0'+check)
+}
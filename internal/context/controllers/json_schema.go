@@ -0,0 +1,13 @@
+package validator_controller
+
+import (
+	"mocky/internal/context/controllers/jsonschema"
+)
+
+func jsonSchemaErrors(failures []jsonschema.Error) []ValidationError {
+	var errs []ValidationError
+	for _, e := range failures {
+		errs = append(errs, ValidationError{Path: e.Path, Err: e.Message, Code: keywordCode(e.Keyword), Pointer: e.InstancePath})
+	}
+	return errs
+}
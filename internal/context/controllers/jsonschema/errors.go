@@ -0,0 +1,72 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Error is a single validation failure against a JSON Schema document.
+type Error struct {
+	// InstancePath is the JSON pointer of the failing value ("/items/0/sku").
+	InstancePath string
+	// Path is the same location in dotted form ("items[0].sku").
+	Path    string
+	Keyword string
+	Message string
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// instancePath is the location being validated; segments are string (property) or int (index).
+type instancePath []any
+
+func (p instancePath) child(seg any) instancePath {
+	out := make(instancePath, len(p), len(p)+1)
+	copy(out, p)
+	return append(out, seg)
+}
+
+func (p instancePath) pointer() string {
+	var b strings.Builder
+	for _, seg := range p {
+		b.WriteByte('/')
+		switch s := seg.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(s))
+		case string:
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s))
+		}
+	}
+	return b.String()
+}
+
+func (p instancePath) dotted() string {
+	var b strings.Builder
+	for _, seg := range p {
+		switch s := seg.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(s) + "]")
+		case string:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+func newError(path instancePath, keyword string, format string, args ...any) Error {
+	return Error{
+		InstancePath: path.pointer(),
+		Path:         path.dotted(),
+		Keyword:      keyword,
+		Message:      fmt.Sprintf(format, args...),
+	}
+}
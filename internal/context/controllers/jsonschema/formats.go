@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// formatCheckers implementa los "format" de draft 2020-12 más usados en contratos
+var formatCheckers = map[string]func(s string) bool{
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05Z07:00", strings.ToUpper(s))
+		if err != nil {
+			_, err = time.Parse("15:04:05.999999999Z07:00", strings.ToUpper(s))
+		}
+		return err == nil
+	},
+	"duration": func(s string) bool {
+		return durationRe.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T")
+	},
+	"email":     IsEmail,
+	"idn-email": IsEmail,
+	"hostname":  isHostname,
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && strings.Count(s, ".") == 3 && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": func(s string) bool { return uuidRe.MatchString(s) },
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+	"json-pointer": func(s string) bool {
+		return s == "" || (strings.HasPrefix(s, "/") && !invalidPointerEscapeRe.MatchString(s))
+	},
+
+	// No son parte de la spec pero aparecen en nuestros contratos
+	"e164": func(s string) bool { return e164Re.MatchString(s) },
+	"base64": func(s string) bool {
+		_, err := base64.StdEncoding.DecodeString(s)
+		return err == nil && s != ""
+	},
+	"jwt": isJWT,
+}
+
+var (
+	emailRe                = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+	hostnameLabelRe        = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9\-]{0,61}[A-Za-z0-9])?$`)
+	uuidRe                 = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	durationRe             = regexp.MustCompile(`^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+	invalidPointerEscapeRe = regexp.MustCompile(`~[^01]|~$`)
+	e164Re                 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+)
+
+// IsEmail es el chequeo de format "email"; lo usa también la validación de bodySchema
+func IsEmail(s string) bool { return emailRe.MatchString(s) }
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !hostnameLabelRe.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isJWT verifica la forma header.payload.signature; header y payload deben ser JSON (no verifica la firma)
+func isJWT(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for _, part := range parts[:2] {
+		decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+		if err != nil {
+			return false
+		}
+		var obj map[string]any
+		if json.Unmarshal(decoded, &obj) != nil {
+			return false
+		}
+	}
+	_, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	return err == nil
+}
+
+// IsKnownFormat indica si name es uno de los formats incluidos
+func IsKnownFormat(name string) bool {
+	_, ok := formatCheckers[name]
+	return ok
+}
+
+// CheckFormat valida s contra un format conocido. known=false para formats que
+// no reconocemos: según la spec se ignoran en lugar de fallar.
+func CheckFormat(name string, s string) (known bool, ok bool) {
+	checker, exists := formatCheckers[name]
+	if !exists {
+		return false, true
+	}
+	return true, checker(s)
+}
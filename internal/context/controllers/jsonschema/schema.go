@@ -0,0 +1,664 @@
+// Package jsonschema validates request bodies against standard JSON Schema
+// (draft 2020-12) documents. Only local references are supported: "#",
+// JSON pointers ("#/$defs/address"), anchors ("#node") and "$id"s declared
+// inside the same document.
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema is a compiled JSON Schema (or one of its subschemas).
+type Schema struct {
+	location string
+
+	// boolean schemas: true accepts everything, false rejects everything
+	always *bool
+
+	ref    *Schema
+	refURI string
+
+	types    []string
+	enum     []any
+	hasConst bool
+	constVal any
+
+	// numbers
+	multipleOf       *float64
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+
+	// strings
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	// objects
+	properties            map[string]*Schema
+	patternProperties     []patternSchema
+	additionalProperties  *Schema
+	propertyNames         *Schema
+	required              []string
+	dependentRequired     map[string][]string
+	dependentSchemas      map[string]*Schema
+	minProperties         *int
+	maxProperties         *int
+	unevaluatedProperties *Schema
+
+	// arrays
+	prefixItems      []*Schema
+	items            *Schema
+	contains         *Schema
+	minContains      *int
+	maxContains      *int
+	minItems         *int
+	maxItems         *int
+	uniqueItems      bool
+	unevaluatedItems *Schema
+
+	// composition
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+	ifS   *Schema
+	thenS *Schema
+	elseS *Schema
+}
+
+type patternSchema struct {
+	re     *regexp.Regexp
+	schema *Schema
+}
+
+// Compile parses a JSON Schema document (as decoded by encoding/json) and
+// resolves all of its references. Invalid keywords and unresolvable $refs are errors.
+func Compile(doc any) (*Schema, error) {
+	c := &compiler{
+		root:      doc,
+		byPointer: map[string]*Schema{},
+		byURI:     map[string]string{},
+	}
+
+	base := &url.URL{}
+	if m, ok := doc.(map[string]any); ok {
+		if id, ok := m["$id"].(string); ok {
+			if u, err := url.Parse(id); err == nil {
+				base = u
+			}
+		}
+	}
+
+	c.collectIDs(doc, "", base)
+
+	root, err := c.compile(doc, "", base)
+	if err != nil {
+		return nil, err
+	}
+
+	// las referencias se resuelven al final para soportar esquemas recursivos
+	for len(c.pending) > 0 {
+		s := c.pending[0]
+		c.pending = c.pending[1:]
+		target, err := c.resolveRef(s)
+		if err != nil {
+			return nil, err
+		}
+		s.ref = target
+	}
+
+	if err := checkCycles(c.byPointer); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// sameInstance devuelve los subschemas que se evalúan contra el mismo valor (sin bajar a
+// una propiedad o un item): $ref y los keywords de composición
+func (s *Schema) sameInstance() []*Schema {
+	next := make([]*Schema, 0, len(s.allOf)+len(s.anyOf)+len(s.oneOf)+len(s.dependentSchemas)+5)
+	for _, candidate := range []*Schema{s.ref, s.not, s.ifS, s.thenS, s.elseS} {
+		if candidate != nil {
+			next = append(next, candidate)
+		}
+	}
+	next = append(next, s.allOf...)
+	next = append(next, s.anyOf...)
+	next = append(next, s.oneOf...)
+	for _, name := range sortedKeys(s.dependentSchemas) {
+		next = append(next, s.dependentSchemas[name])
+	}
+	return next
+}
+
+// checkCycles rejects schemas that reach themselves without descending into the
+// instance ({"$ref": "#"}, $defs a -> b -> a, {"allOf": [{"$ref": "#"}]}): validating
+// them would recurse forever.
+func checkCycles(schemas map[string]*Schema) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*Schema]int{}
+
+	var visit func(s *Schema, chain []string) error
+	visit = func(s *Schema, chain []string) error {
+		switch state[s] {
+		case visiting:
+			return fmt.Errorf("$ref cycle %s -> %s never reaches a property or item; it would never finish validating", strings.Join(chain, " -> "), s.location)
+		case done:
+			return nil
+		}
+		state[s] = visiting
+		for _, next := range s.sameInstance() {
+			if err := visit(next, append(chain, s.location)); err != nil {
+				return err
+			}
+		}
+		state[s] = done
+		return nil
+	}
+
+	for _, pointer := range sortedKeys(schemas) {
+		if err := visit(schemas[pointer], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type compiler struct {
+	root      any
+	byPointer map[string]*Schema
+	// URI absoluta ($id o $anchor) -> JSON pointer dentro del documento
+	byURI   map[string]string
+	pending []*Schema
+	bases   map[string]*url.URL
+}
+
+// collectIDs registra $id y $anchor de todo el documento antes de compilar,
+// para que un $ref pueda apuntar a un subschema declarado más adelante.
+func (c *compiler) collectIDs(node any, pointer string, base *url.URL) {
+	if c.bases == nil {
+		c.bases = map[string]*url.URL{}
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		if arr, ok := node.([]any); ok {
+			for i, item := range arr {
+				c.collectIDs(item, fmt.Sprintf("%s/%d", pointer, i), base)
+			}
+		}
+		return
+	}
+
+	if id, ok := m["$id"].(string); ok {
+		if u, err := url.Parse(id); err == nil {
+			base = base.ResolveReference(u)
+			c.byURI[withoutFragment(base)] = pointer
+		}
+	}
+	c.bases[pointer] = base
+	if anchor, ok := m["$anchor"].(string); ok {
+		c.byURI[withoutFragment(base)+"#"+anchor] = pointer
+	}
+
+	for key, val := range m {
+		// enum y const son datos, no subschemas
+		if key == "enum" || key == "const" || key == "examples" || key == "default" {
+			continue
+		}
+		c.collectIDs(val, pointer+"/"+escapePointer(key), base)
+	}
+}
+
+func withoutFragment(u *url.URL) string {
+	clone := *u
+	clone.Fragment = ""
+	return clone.String()
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func unescapePointer(s string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+}
+
+func (c *compiler) compile(node any, pointer string, base *url.URL) (*Schema, error) {
+	if s, ok := c.byPointer[pointer]; ok {
+		return s, nil
+	}
+
+	s := &Schema{location: "#" + pointer}
+	c.byPointer[pointer] = s
+
+	switch v := node.(type) {
+	case bool:
+		s.always = &v
+		return s, nil
+	case map[string]any:
+		if b, ok := c.bases[pointer]; ok {
+			base = b
+		}
+		if err := c.compileKeywords(s, v, pointer, base); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("schema at %s must be an object or a boolean", s.location)
+	}
+}
+
+func (c *compiler) compileKeywords(s *Schema, m map[string]any, pointer string, base *url.URL) error {
+	var err error
+	sub := func(key string) (*Schema, error) {
+		val, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		return c.compile(val, pointer+"/"+escapePointer(key), base)
+	}
+	subList := func(key string) ([]*Schema, error) {
+		val, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		arr, ok := val.([]any)
+		if !ok || len(arr) == 0 {
+			return nil, fmt.Errorf("%s at #%s must be a non-empty array of schemas", key, pointer)
+		}
+		out := make([]*Schema, len(arr))
+		for i, item := range arr {
+			if out[i], err = c.compile(item, fmt.Sprintf("%s/%s/%d", pointer, key, i), base); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	subMap := func(key string) (map[string]*Schema, error) {
+		val, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s at #%s must be an object", key, pointer)
+		}
+		out := make(map[string]*Schema, len(obj))
+		for name, item := range obj {
+			if out[name], err = c.compile(item, pointer+"/"+key+"/"+escapePointer(name), base); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	// ---- $ref ----
+	if ref, ok := m["$ref"]; ok {
+		refStr, isString := ref.(string)
+		if !isString {
+			return fmt.Errorf("$ref at #%s must be a string", pointer)
+		}
+		u, parseErr := url.Parse(refStr)
+		if parseErr != nil {
+			return fmt.Errorf("invalid $ref %q at #%s: %v", refStr, pointer, parseErr)
+		}
+		s.refURI = base.ResolveReference(u).String()
+		c.pending = append(c.pending, s)
+	}
+
+	// ---- type / enum / const ----
+	if t, ok := m["type"]; ok {
+		if s.types, err = stringOrList(t); err != nil {
+			return fmt.Errorf("type at #%s: %v", pointer, err)
+		}
+		for _, name := range s.types {
+			if !knownTypes[name] {
+				return fmt.Errorf("type at #%s: unknown type %q", pointer, name)
+			}
+		}
+	}
+	if e, ok := m["enum"]; ok {
+		arr, isArr := e.([]any)
+		if !isArr {
+			return fmt.Errorf("enum at #%s must be an array", pointer)
+		}
+		s.enum = arr
+	}
+	if cv, ok := m["const"]; ok {
+		s.hasConst = true
+		s.constVal = cv
+	}
+
+	// ---- numbers ----
+	for key, dst := range map[string]**float64{
+		"multipleOf":       &s.multipleOf,
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+	} {
+		if val, ok := m[key]; ok {
+			n, isNum := toFloat(val)
+			if !isNum {
+				return fmt.Errorf("%s at #%s must be a number", key, pointer)
+			}
+			*dst = &n
+		}
+	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		return fmt.Errorf("multipleOf at #%s must be greater than 0", pointer)
+	}
+
+	// ---- counters ----
+	for key, dst := range map[string]**int{
+		"minLength":     &s.minLength,
+		"maxLength":     &s.maxLength,
+		"minProperties": &s.minProperties,
+		"maxProperties": &s.maxProperties,
+		"minItems":      &s.minItems,
+		"maxItems":      &s.maxItems,
+		"minContains":   &s.minContains,
+		"maxContains":   &s.maxContains,
+	} {
+		if val, ok := m[key]; ok {
+			n, isNum := toFloat(val)
+			if !isNum || n < 0 || n != float64(int(n)) {
+				return fmt.Errorf("%s at #%s must be a non-negative integer", key, pointer)
+			}
+			i := int(n)
+			*dst = &i
+		}
+	}
+
+	// ---- strings ----
+	if p, ok := m["pattern"]; ok {
+		ps, isString := p.(string)
+		if !isString {
+			return fmt.Errorf("pattern at #%s must be a string", pointer)
+		}
+		if s.pattern, err = regexp.Compile(ps); err != nil {
+			return fmt.Errorf("pattern at #%s is not a valid regex: %v", pointer, err)
+		}
+	}
+	if f, ok := m["format"]; ok {
+		fs, isString := f.(string)
+		if !isString {
+			return fmt.Errorf("format at #%s must be a string", pointer)
+		}
+		s.format = fs
+	}
+
+	// ---- objects ----
+	if s.properties, err = subMap("properties"); err != nil {
+		return err
+	}
+	if pp, ok := m["patternProperties"]; ok {
+		obj, isObj := pp.(map[string]any)
+		if !isObj {
+			return fmt.Errorf("patternProperties at #%s must be an object", pointer)
+		}
+		patterns := make([]string, 0, len(obj))
+		for p := range obj {
+			patterns = append(patterns, p)
+		}
+		sort.Strings(patterns)
+		for _, p := range patterns {
+			re, reErr := regexp.Compile(p)
+			if reErr != nil {
+				return fmt.Errorf("patternProperties at #%s: %q is not a valid regex: %v", pointer, p, reErr)
+			}
+			ps, compErr := c.compile(obj[p], pointer+"/patternProperties/"+escapePointer(p), base)
+			if compErr != nil {
+				return compErr
+			}
+			s.patternProperties = append(s.patternProperties, patternSchema{re: re, schema: ps})
+		}
+	}
+	if s.additionalProperties, err = sub("additionalProperties"); err != nil {
+		return err
+	}
+	if s.propertyNames, err = sub("propertyNames"); err != nil {
+		return err
+	}
+	if s.unevaluatedProperties, err = sub("unevaluatedProperties"); err != nil {
+		return err
+	}
+	if r, ok := m["required"]; ok {
+		if s.required, err = stringList(r); err != nil {
+			return fmt.Errorf("required at #%s: %v", pointer, err)
+		}
+	}
+	if dr, ok := m["dependentRequired"]; ok {
+		if s.dependentRequired, err = dependentRequired(dr); err != nil {
+			return fmt.Errorf("dependentRequired at #%s: %v", pointer, err)
+		}
+	}
+	if s.dependentSchemas, err = subMap("dependentSchemas"); err != nil {
+		return err
+	}
+	// "dependencies" (draft 7) se acepta como dependentRequired / dependentSchemas
+	if deps, ok := m["dependencies"].(map[string]any); ok {
+		for name, dep := range deps {
+			if _, isList := dep.([]any); isList {
+				fields, listErr := stringList(dep)
+				if listErr != nil {
+					return fmt.Errorf("dependencies at #%s: %v", pointer, listErr)
+				}
+				if s.dependentRequired == nil {
+					s.dependentRequired = map[string][]string{}
+				}
+				s.dependentRequired[name] = fields
+				continue
+			}
+			ds, compErr := c.compile(dep, pointer+"/dependencies/"+escapePointer(name), base)
+			if compErr != nil {
+				return compErr
+			}
+			if s.dependentSchemas == nil {
+				s.dependentSchemas = map[string]*Schema{}
+			}
+			s.dependentSchemas[name] = ds
+		}
+	}
+
+	// ---- arrays ----
+	if s.prefixItems, err = subList("prefixItems"); err != nil {
+		return err
+	}
+	if items, ok := m["items"]; ok {
+		if _, isList := items.([]any); isList {
+			// "items": [...] (draft 7) equivale a prefixItems
+			if s.prefixItems, err = subList("items"); err != nil {
+				return err
+			}
+			if s.items, err = sub("additionalItems"); err != nil {
+				return err
+			}
+		} else if s.items, err = sub("items"); err != nil {
+			return err
+		}
+	}
+	if s.contains, err = sub("contains"); err != nil {
+		return err
+	}
+	if u, ok := m["uniqueItems"]; ok {
+		b, isBool := u.(bool)
+		if !isBool {
+			return fmt.Errorf("uniqueItems at #%s must be a boolean", pointer)
+		}
+		s.uniqueItems = b
+	}
+	if s.unevaluatedItems, err = sub("unevaluatedItems"); err != nil {
+		return err
+	}
+
+	// ---- composition ----
+	if s.allOf, err = subList("allOf"); err != nil {
+		return err
+	}
+	if s.anyOf, err = subList("anyOf"); err != nil {
+		return err
+	}
+	if s.oneOf, err = subList("oneOf"); err != nil {
+		return err
+	}
+	if s.not, err = sub("not"); err != nil {
+		return err
+	}
+	if s.ifS, err = sub("if"); err != nil {
+		return err
+	}
+	if s.thenS, err = sub("then"); err != nil {
+		return err
+	}
+	if s.elseS, err = sub("else"); err != nil {
+		return err
+	}
+
+	// $defs / definitions se compilan para detectar errores aunque nadie los referencie
+	for _, key := range []string{"$defs", "definitions"} {
+		if _, err = subMap(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *compiler) resolveRef(s *Schema) (*Schema, error) {
+	u, err := url.Parse(s.refURI)
+	if err != nil {
+		return nil, err
+	}
+	fragment := u.Fragment
+	u.Fragment = ""
+	doc := u.String()
+
+	// #anchor
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		pointer, ok := c.byURI[doc+"#"+fragment]
+		if !ok {
+			return nil, fmt.Errorf("$ref %q at %s: anchor not found", s.refURI, s.location)
+		}
+		return c.schemaAt(pointer, s)
+	}
+
+	basePointer, ok := c.byURI[doc]
+	if !ok {
+		if doc != "" {
+			return nil, fmt.Errorf("$ref %q at %s: remote references are not supported", s.refURI, s.location)
+		}
+		basePointer = ""
+	}
+	return c.schemaAt(basePointer+fragment, s)
+}
+
+func (c *compiler) schemaAt(pointer string, from *Schema) (*Schema, error) {
+	if target, ok := c.byPointer[pointer]; ok {
+		return target, nil
+	}
+	node, ok := lookupPointer(c.root, pointer)
+	if !ok {
+		return nil, fmt.Errorf("$ref %q at %s: #%s not found", from.refURI, from.location, pointer)
+	}
+	return c.compile(node, pointer, c.baseFor(pointer))
+}
+
+// baseFor busca el $id más cercano hacia arriba del pointer
+func (c *compiler) baseFor(pointer string) *url.URL {
+	for p := pointer; ; {
+		if b, ok := c.bases[p]; ok {
+			return b
+		}
+		idx := strings.LastIndex(p, "/")
+		if idx < 0 {
+			return &url.URL{}
+		}
+		p = p[:idx]
+	}
+}
+
+func lookupPointer(doc any, pointer string) (any, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if p, err := url.PathUnescape(pointer); err == nil {
+		pointer = p
+	}
+	current := doc
+	for _, raw := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token := unescapePointer(raw)
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			var idx int
+			if _, err := fmt.Sscanf(token, "%d", &idx); err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			current = node[idx]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+var knownTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+func stringOrList(v any) ([]string, error) {
+	if s, ok := v.(string); ok {
+		return []string{s}, nil
+	}
+	return stringList(v)
+}
+
+func stringList(v any) ([]string, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an array of strings")
+	}
+	out := make([]string, len(arr))
+	for i, item := range arr {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be an array of strings")
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+func dependentRequired(v any) (map[string][]string, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an object")
+	}
+	out := make(map[string][]string, len(obj))
+	for name, deps := range obj {
+		fields, err := stringList(deps)
+		if err != nil {
+			return nil, fmt.Errorf("%s %v", name, err)
+		}
+		out[name] = fields
+	}
+	return out, nil
+}
@@ -0,0 +1,472 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate checks instance (as decoded by encoding/json) and returns every failure found.
+func (s *Schema) Validate(instance any) []Error {
+	var errs []Error
+	s.validate(instance, nil, &errs)
+	return errs
+}
+
+// evaluated keeps the properties/items already covered by some keyword, needed by
+// unevaluatedProperties and unevaluatedItems.
+type evaluated struct {
+	props    map[string]bool
+	items    map[int]bool
+	allItems bool
+}
+
+func (e *evaluated) merge(other evaluated) {
+	for k := range other.props {
+		e.markProp(k)
+	}
+	for i := range other.items {
+		e.markItem(i)
+	}
+	if other.allItems {
+		e.allItems = true
+	}
+}
+
+func (e *evaluated) markProp(name string) {
+	if e.props == nil {
+		e.props = map[string]bool{}
+	}
+	e.props[name] = true
+}
+
+func (e *evaluated) markItem(i int) {
+	if e.items == nil {
+		e.items = map[int]bool{}
+	}
+	e.items[i] = true
+}
+
+// try validates into a scratch list, used by anyOf/oneOf/not/if where failures are expected
+func (s *Schema) try(instance any, path instancePath) (bool, evaluated, []Error) {
+	var errs []Error
+	ev := s.validate(instance, path, &errs)
+	return len(errs) == 0, ev, errs
+}
+
+func (s *Schema) validate(instance any, path instancePath, errs *[]Error) evaluated {
+	var ev evaluated
+
+	if s.always != nil {
+		if !*s.always {
+			*errs = append(*errs, newError(path, "false", "no value is allowed here"))
+		}
+		return ev
+	}
+
+	if s.ref != nil {
+		ev.merge(s.ref.validate(instance, path, errs))
+	}
+
+	s.validateType(instance, path, errs)
+
+	if s.enum != nil {
+		found := false
+		for _, option := range s.enum {
+			if equal(instance, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs, newError(path, "enum", "value must be one of %s", describe(s.enum)))
+		}
+	}
+	if s.hasConst && !equal(instance, s.constVal) {
+		*errs = append(*errs, newError(path, "const", "value must be %s", describe(s.constVal)))
+	}
+
+	switch v := instance.(type) {
+	case string:
+		s.validateString(v, path, errs)
+	case map[string]any:
+		ev.merge(s.validateObject(v, path, errs))
+	case []any:
+		ev.merge(s.validateArray(v, path, errs))
+	default:
+		if n, ok := toFloat(v); ok {
+			s.validateNumber(n, path, errs)
+		}
+	}
+
+	ev.merge(s.validateComposition(instance, path, errs))
+
+	// unevaluated* van al final: necesitan lo evaluado por todos los demás keywords
+	if obj, ok := instance.(map[string]any); ok && s.unevaluatedProperties != nil {
+		for _, name := range sortedKeys(obj) {
+			if ev.props[name] {
+				continue
+			}
+			if ok, _, _ := s.unevaluatedProperties.try(obj[name], path.child(name)); !ok {
+				*errs = append(*errs, newError(path.child(name), "unevaluatedProperties", "property not allowed"))
+				continue
+			}
+			ev.markProp(name)
+		}
+	}
+	if arr, ok := instance.([]any); ok && s.unevaluatedItems != nil && !ev.allItems {
+		for i, item := range arr {
+			if ev.items[i] {
+				continue
+			}
+			if ok, _, _ := s.unevaluatedItems.try(item, path.child(i)); !ok {
+				*errs = append(*errs, newError(path.child(i), "unevaluatedItems", "item not allowed"))
+				continue
+			}
+			ev.markItem(i)
+		}
+	}
+
+	return ev
+}
+
+func (s *Schema) validateType(instance any, path instancePath, errs *[]Error) {
+	if len(s.types) == 0 {
+		return
+	}
+	actual := typeOf(instance)
+	for _, t := range s.types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+	}
+	*errs = append(*errs, newError(path, "type", "expected %s, got %s", strings.Join(s.types, " or "), actual))
+}
+
+func (s *Schema) validateNumber(n float64, path instancePath, errs *[]Error) {
+	if s.minimum != nil && n < *s.minimum {
+		*errs = append(*errs, newError(path, "minimum", "must be >= %v", *s.minimum))
+	}
+	if s.maximum != nil && n > *s.maximum {
+		*errs = append(*errs, newError(path, "maximum", "must be <= %v", *s.maximum))
+	}
+	if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+		*errs = append(*errs, newError(path, "exclusiveMinimum", "must be > %v", *s.exclusiveMinimum))
+	}
+	if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+		*errs = append(*errs, newError(path, "exclusiveMaximum", "must be < %v", *s.exclusiveMaximum))
+	}
+	if s.multipleOf != nil && !IsMultipleOf(n, *s.multipleOf) {
+		*errs = append(*errs, newError(path, "multipleOf", "must be a multiple of %v", *s.multipleOf))
+	}
+}
+
+// IsMultipleOf tolera el error de punto flotante (0.3 es múltiplo de 0.1)
+func IsMultipleOf(n, m float64) bool {
+	q := n / m
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+func (s *Schema) validateString(v string, path instancePath, errs *[]Error) {
+	length := utf8.RuneCountInString(v)
+	if s.minLength != nil && length < *s.minLength {
+		*errs = append(*errs, newError(path, "minLength", "minimum length is %d", *s.minLength))
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		*errs = append(*errs, newError(path, "maxLength", "maximum length is %d", *s.maxLength))
+	}
+	if s.pattern != nil && !s.pattern.MatchString(v) {
+		*errs = append(*errs, newError(path, "pattern", "value does not match pattern %s", s.pattern.String()))
+	}
+	if s.format != "" {
+		if known, ok := CheckFormat(s.format, v); known && !ok {
+			*errs = append(*errs, newError(path, "format", "invalid %s format", s.format))
+		}
+	}
+}
+
+func (s *Schema) validateObject(obj map[string]any, path instancePath, errs *[]Error) evaluated {
+	var ev evaluated
+
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, newError(path.child(name), "required", "missing required field"))
+		}
+	}
+	if s.minProperties != nil && len(obj) < *s.minProperties {
+		*errs = append(*errs, newError(path, "minProperties", "must have at least %d properties", *s.minProperties))
+	}
+	if s.maxProperties != nil && len(obj) > *s.maxProperties {
+		*errs = append(*errs, newError(path, "maxProperties", "must have at most %d properties", *s.maxProperties))
+	}
+
+	for _, name := range sortedKeys(s.dependentRequired) {
+		if _, present := obj[name]; !present {
+			continue
+		}
+		for _, dep := range s.dependentRequired[name] {
+			if _, ok := obj[dep]; !ok {
+				*errs = append(*errs, newError(path.child(dep), "dependentRequired", "required when %s is present", name))
+			}
+		}
+	}
+	for _, name := range sortedKeys(s.dependentSchemas) {
+		if _, present := obj[name]; present {
+			ev.merge(s.dependentSchemas[name].validate(obj, path, errs))
+		}
+	}
+
+	for _, name := range sortedKeys(obj) {
+		val := obj[name]
+		childPath := path.child(name)
+
+		if s.propertyNames != nil {
+			if ok, _, _ := s.propertyNames.try(name, path); !ok {
+				*errs = append(*errs, newError(childPath, "propertyNames", "invalid property name"))
+			}
+		}
+
+		matched := false
+		if ps, ok := s.properties[name]; ok {
+			ps.validate(val, childPath, errs)
+			matched = true
+		}
+		for _, pp := range s.patternProperties {
+			if pp.re.MatchString(name) {
+				pp.schema.validate(val, childPath, errs)
+				matched = true
+			}
+		}
+		if matched {
+			ev.markProp(name)
+			continue
+		}
+		if s.additionalProperties != nil {
+			if s.additionalProperties.always != nil && !*s.additionalProperties.always {
+				*errs = append(*errs, newError(childPath, "additionalProperties", "property not allowed (additionalProperties=false)"))
+				continue
+			}
+			s.additionalProperties.validate(val, childPath, errs)
+			ev.markProp(name)
+		}
+	}
+	return ev
+}
+
+func (s *Schema) validateArray(arr []any, path instancePath, errs *[]Error) evaluated {
+	var ev evaluated
+
+	if s.minItems != nil && len(arr) < *s.minItems {
+		*errs = append(*errs, newError(path, "minItems", "must have at least %d items", *s.minItems))
+	}
+	if s.maxItems != nil && len(arr) > *s.maxItems {
+		*errs = append(*errs, newError(path, "maxItems", "must have at most %d items", *s.maxItems))
+	}
+	if s.uniqueItems {
+		for i := 0; i < len(arr); i++ {
+			for j := 0; j < i; j++ {
+				if equal(arr[i], arr[j]) {
+					*errs = append(*errs, newError(path.child(i), "uniqueItems", "duplicated item (same as index %d)", j))
+					break
+				}
+			}
+		}
+	}
+
+	for i, item := range arr {
+		switch {
+		case i < len(s.prefixItems):
+			s.prefixItems[i].validate(item, path.child(i), errs)
+			ev.markItem(i)
+		case s.items != nil:
+			s.items.validate(item, path.child(i), errs)
+		}
+	}
+	if s.items != nil {
+		ev.allItems = true
+	}
+
+	if s.contains != nil {
+		matches := 0
+		for i, item := range arr {
+			if ok, _, _ := s.contains.try(item, path.child(i)); ok {
+				matches++
+				ev.markItem(i)
+			}
+		}
+		minContains := 1
+		if s.minContains != nil {
+			minContains = *s.minContains
+		}
+		if matches < minContains {
+			*errs = append(*errs, newError(path, "contains", "must contain at least %d matching items (found %d)", minContains, matches))
+		}
+		if s.maxContains != nil && matches > *s.maxContains {
+			*errs = append(*errs, newError(path, "maxContains", "must contain at most %d matching items (found %d)", *s.maxContains, matches))
+		}
+	}
+	return ev
+}
+
+func (s *Schema) validateComposition(instance any, path instancePath, errs *[]Error) evaluated {
+	var ev evaluated
+
+	for _, sub := range s.allOf {
+		ev.merge(sub.validate(instance, path, errs))
+	}
+
+	if len(s.anyOf) > 0 {
+		matched := false
+		for _, sub := range s.anyOf {
+			if ok, subEv, _ := sub.try(instance, path); ok {
+				matched = true
+				ev.merge(subEv)
+			}
+		}
+		if !matched {
+			*errs = append(*errs, newError(path, "anyOf", "must match at least one schema in anyOf"))
+		}
+	}
+
+	if len(s.oneOf) > 0 {
+		var matches []int
+		var lastErrs []Error
+		for i, sub := range s.oneOf {
+			ok, subEv, subErrs := sub.try(instance, path)
+			if ok {
+				matches = append(matches, i)
+				ev.merge(subEv)
+				continue
+			}
+			lastErrs = subErrs
+		}
+		switch {
+		case len(matches) == 0 && len(s.oneOf) == 1:
+			*errs = append(*errs, lastErrs...)
+		case len(matches) == 0:
+			*errs = append(*errs, newError(path, "oneOf", "must match exactly one schema in oneOf (matched none)"))
+		case len(matches) > 1:
+			*errs = append(*errs, newError(path, "oneOf", "must match exactly one schema in oneOf (matched %v)", matches))
+		}
+	}
+
+	if s.not != nil {
+		if ok, _, _ := s.not.try(instance, path); ok {
+			*errs = append(*errs, newError(path, "not", "must not match the schema in not"))
+		}
+	}
+
+	if s.ifS != nil {
+		if ok, ifEv, _ := s.ifS.try(instance, path); ok {
+			ev.merge(ifEv)
+			if s.thenS != nil {
+				ev.merge(s.thenS.validate(instance, path, errs))
+			}
+		} else if s.elseS != nil {
+			ev.merge(s.elseS.validate(instance, path, errs))
+		}
+	}
+
+	return ev
+}
+
+// ======== Utils ========
+
+func typeOf(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		if n, ok := toFloat(x); ok {
+			if n == math.Trunc(n) && !math.IsInf(n, 0) {
+				return "integer"
+			}
+			return "number"
+		}
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// equal compara valores JSON; los números se comparan por valor (1 == 1.0)
+func equal(a, b any) bool {
+	if na, ok := toFloat(a); ok {
+		nb, ok := toFloat(b)
+		return ok && na == nb
+	}
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func describe(v any) string {
+	if arr, ok := v.([]any); ok {
+		parts := make([]string, len(arr))
+		for i, item := range arr {
+			parts[i] = describe(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
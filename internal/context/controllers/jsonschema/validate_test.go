@@ -0,0 +1,131 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// suiteGroup mirrors a test group of the JSON-Schema-Test-Suite
+// (https://github.com/json-schema-org/JSON-Schema-Test-Suite); testdata holds a
+// subset of its draft2020-12 cases.
+type suiteGroup struct {
+	Description string `json:"description"`
+	Schema      any    `json:"schema"`
+	Tests       []struct {
+		Description string `json:"description"`
+		Data        any    `json:"data"`
+		Valid       bool   `json:"valid"`
+	} `json:"tests"`
+}
+
+func TestSuite(t *testing.T) {
+	raw, err := os.ReadFile("testdata/draft2020-12.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var groups []suiteGroup
+	if err := json.Unmarshal(raw, &groups); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, group := range groups {
+		t.Run(group.Description, func(t *testing.T) {
+			schema, err := Compile(group.Schema)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			for _, tt := range group.Tests {
+				t.Run(tt.Description, func(t *testing.T) {
+					errs := schema.Validate(tt.Data)
+					if valid := len(errs) == 0; valid != tt.Valid {
+						t.Fatalf("valid = %v, want %v (%v)", valid, tt.Valid, errs)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestCompileRejectsRefCycles(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"root references itself", `{"$ref": "#"}`},
+		{"defs reference each other", `{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`},
+		{"composition references the root", `{"allOf": [{"$ref": "#"}]}`},
+		{"if references itself", `{"$defs": {"a": {"if": {"$ref": "#/$defs/a"}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc any
+			if err := json.Unmarshal([]byte(tt.schema), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Compile(doc); err == nil {
+				t.Fatal("expected a $ref cycle error")
+			}
+		})
+	}
+}
+
+func TestCompileRejectsUnresolvableRef(t *testing.T) {
+	var doc any
+	if err := json.Unmarshal([]byte(`{"properties": {"a": {"$ref": "#/$defs/missing"}}}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Compile(doc); err == nil {
+		t.Fatal("expected an unresolvable $ref error")
+	}
+}
+
+func TestErrorLocation(t *testing.T) {
+	var doc any
+	schema := `{
+		"type": "object",
+		"properties": {
+			"items": {"type": "array", "items": {"type": "object", "required": ["sku"]}},
+			"coupon": {"type": "string"},
+			"total": {"type": "number"}
+		},
+		"dependentRequired": {"coupon": ["total"]},
+		"unevaluatedProperties": false
+	}`
+	if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := Compile(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		pointer string
+		path    string
+		keyword string
+	}{
+		{"required inside an item", `{"items": [{"sku": "a"}, {}]}`, "/items/1/sku", "items[1].sku", "required"},
+		{"dependent required", `{"coupon": "x"}`, "/total", "total", "dependentRequired"},
+		{"unevaluated property", `{"extra": 1}`, "/extra", "extra", "unevaluatedProperties"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.data), &data); err != nil {
+				t.Fatal(err)
+			}
+			errs := compiled.Validate(data)
+			if len(errs) != 1 {
+				t.Fatalf("errors = %v, want 1", errs)
+			}
+			if errs[0].InstancePath != tt.pointer || errs[0].Path != tt.path || errs[0].Keyword != tt.keyword {
+				t.Fatalf("got %+v, want pointer %q, path %q, keyword %q", errs[0], tt.pointer, tt.path, tt.keyword)
+			}
+		})
+	}
+}
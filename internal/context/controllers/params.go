@@ -0,0 +1,176 @@
+package validator_controller
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"mocky/internal/api/v1/prototypes/domain/entities"
+)
+
+// Locations of the typed params
+const (
+	InBody   = "body"
+	InQuery  = "query"
+	InHeader = "header"
+	InPath   = "path"
+
+	// InResponse marks the errors of the response contract (response.schema)
+	InResponse = "response"
+)
+
+// ParamError builds the error for a single header, query or path param (in = InHeader,
+// InQuery or InPath), with the same Path and Pointer as the ones ValidateParams reports.
+func ParamError(in, name, code, message string) ValidationError {
+	return ValidationError{Path: joinPath(in, name), Err: message, Code: code, In: in, Pointer: toPointer(joinPath("", name))}
+}
+
+// validateParams validates query params or headers (in = "query" | "header") with the
+// same rules as the body. Values arrive as strings, so they are coerced to the declared
+// type first; "array" properties receive every repeated value. Defaults are written into
+// values so templates can read them ({{query.page}}).
+func validateParams(in string, props []entities.PropertyEntity, values map[string][]string, registry map[string]ValidationFunc) []ValidationError {
+	if len(props) == 0 {
+		return nil
+	}
+
+	var errs []ValidationError
+	payload := make(map[string]any, len(props))
+
+	for _, p := range props {
+		received := paramValues(in, p.Name, values)
+		if len(received) == 0 {
+			continue
+		}
+
+		if strings.ToLower(p.Type) == "array" {
+			items := make([]any, len(received))
+			for i, raw := range received {
+				items[i] = raw
+				if p.Items != nil {
+					items[i] = coerceParam(p.Items.Type, raw)
+				}
+			}
+			payload[p.Name] = items
+			continue
+		}
+
+		if len(received) > 1 {
+			errs = append(errs, ValidationError{Path: joinPath(in, p.Name), Err: fmt.Sprintf("expected a single value, got %d (use type array for repeated values)", len(received)), Code: CodeSingleValue, In: in, Pointer: toPointer(joinPath("", p.Name))})
+			continue
+		}
+		payload[p.Name] = coerceParam(p.Type, received[0])
+	}
+
+	schema := entities.BodySchemaEntity{
+		Name:                in,
+		TypeSchema:          "object",
+		AditionalProperties: true,
+		Properties:          props,
+	}
+	for _, err := range ValidateAgainstSchema(schema, payload, registry) {
+		err.In, err.Pointer = in, toPointer(err.Path)
+		err.Path = joinPath(in, err.Path)
+		errs = append(errs, err)
+	}
+
+	// Defaults
+	for _, p := range props {
+		if len(paramValues(in, p.Name, values)) > 0 || p.Default == nil || values == nil {
+			continue
+		}
+		values[paramKey(in, p.Name)] = defaultParamValues(p.Default)
+	}
+
+	return errs
+}
+
+// paramKey normalizes the name: headers are stored in canonical form (X-Request-Id)
+func paramKey(in, name string) string {
+	if in == InHeader {
+		return textproto.CanonicalMIMEHeaderKey(name)
+	}
+	return name
+}
+
+func paramValues(in, name string, values map[string][]string) []string {
+	if received, ok := values[name]; ok {
+		return received
+	}
+	return values[paramKey(in, name)]
+}
+
+// coerceParam converts the string to the declared type; if it can't, it stays a string
+// so the type validator reports the error.
+func coerceParam(typ string, raw string) any {
+	switch strings.ToLower(typ) {
+	case "integer":
+		if n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64); err == nil {
+			return float64(n)
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(strings.TrimSpace(raw)); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+func defaultParamValues(value any) []string {
+	if list, ok := value.([]any); ok {
+		out := make([]string, len(list))
+		for i, item := range list {
+			out[i] = fmt.Sprint(item)
+		}
+		return out
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+// CoerceTextValues converts, in place, the string values of a body that only carries
+// text (form-urlencoded, multipart, XML) to the types declared in the schema. Nested
+// objects and arrays are walked; a single value declared as "array" becomes a list.
+func (v *ValidatorRequest) CoerceTextValues(schema entities.BodySchemaEntity, body map[string]any) {
+	if strings.ToLower(schema.TypeSchema) != "object" {
+		return
+	}
+	coerceObject(schema.Properties, body)
+}
+
+func coerceObject(props []entities.PropertyEntity, body map[string]any) {
+	for _, p := range props {
+		if raw, ok := body[p.Name]; ok {
+			body[p.Name] = coerceTextValue(p, raw)
+		}
+	}
+}
+
+func coerceTextValue(p entities.PropertyEntity, raw any) any {
+	switch strings.ToLower(p.Type) {
+	case "object":
+		if m, ok := raw.(map[string]any); ok {
+			coerceObject(p.Properties, m)
+		}
+		return raw
+	case "array":
+		items, ok := raw.([]any)
+		if !ok {
+			items = []any{raw}
+		}
+		if p.Items != nil {
+			for i, item := range items {
+				items[i] = coerceTextValue(*p.Items, item)
+			}
+		}
+		return items
+	}
+	if s, ok := raw.(string); ok {
+		return coerceParam(p.Type, s)
+	}
+	return raw
+}
@@ -1,17 +1,83 @@
 package placeholder
 
 import (
+	"context"
 	"encoding/json"
+	"net/textproto"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // ==== Contexto ====
 type MockContext struct {
 	PathParams map[string]string
-	Query      map[string]string
-	Headers    map[string]string
+	Query      map[string][]string
+	Headers    map[string][]string
 	Body       map[string]any
+	Request    RequestInfo
+
+	// Context del request, usado para leer datasets
+	Context context.Context
+
+	datasets DatasetSource
+	partials PartialSource
+	state    *resolveState
+
+	// variables globales + las del group del prototype ({{vars.x}})
+	vars map[string]any
+
+	// parámetros del partial que se está resolviendo ({{with.x}})
+	with         map[string]any
+	partialDepth int
+}
+
+// resolveState acumula el primer error que impide construir la respuesta
+type resolveState struct {
+	err error
+}
+
+func (ctx MockContext) fail(err error) {
+	if ctx.state != nil && ctx.state.err == nil {
+		ctx.state.err = err
+	}
+}
+
+func (ctx MockContext) goContext() context.Context {
+	if ctx.Context == nil {
+		return context.Background()
+	}
+	return ctx.Context
+}
+
+// RequestInfo expone metadatos del request bajo el namespace request.*
+type RequestInfo struct {
+	Method         string
+	URL            string
+	Path           string
+	Host           string
+	ClientIP       string
+	TraceID        string
+	PrototypeID    string
+	PrototypeName  string
+	PrototypeGroup string
+	ReceivedAt     time.Time
+	RawBody        string
+}
+
+// requestFields mapea request.<campo> a su valor
+var requestFields = map[string]func(ctx MockContext) any{
+	"method":         func(ctx MockContext) any { return ctx.Request.Method },
+	"url":            func(ctx MockContext) any { return ctx.Request.URL },
+	"path":           func(ctx MockContext) any { return ctx.Request.Path },
+	"host":           func(ctx MockContext) any { return ctx.Request.Host },
+	"clientIp":       func(ctx MockContext) any { return ctx.Request.ClientIP },
+	"traceId":        func(ctx MockContext) any { return ctx.Request.TraceID },
+	"prototypeId":    func(ctx MockContext) any { return ctx.Request.PrototypeID },
+	"prototypeName":  func(ctx MockContext) any { return ctx.Request.PrototypeName },
+	"prototypeGroup": func(ctx MockContext) any { return ctx.Request.PrototypeGroup },
+	"receivedAt":     func(ctx MockContext) any { return ctx.Request.ReceivedAt.UTC().Format(time.RFC3339Nano) },
+	"rawBody":        func(ctx MockContext) any { return ctx.Request.RawBody },
 }
 
 // Utilidad: obtener arg (si no existe, default)
@@ -41,10 +107,11 @@ func parseFuncCall(expr string) (name string, args map[string]string) {
 	return name, args
 }
 
-// parseArgs soporta formato: key:'val', key:"val", key: val (sin comillas, sin comas internas)
+// parseArgs soporta formato: key:'val', key:"val", key: val (sin comillas).
+// Los valores se guardan tal cual (con comillas) para que resolveArgs pueda
+// distinguir literales de referencias al request (body.x, query.y, ...).
 func parseArgs(s string) map[string]string {
 	out := map[string]string{}
-	// split por coma de primer nivel (simple)
 	parts := splitTopLevelComma(s)
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
@@ -57,135 +124,335 @@ func parseArgs(s string) map[string]string {
 		}
 		k := strings.TrimSpace(kv[0])
 		v := strings.TrimSpace(kv[1])
-		// quitar comillas simples/dobles si existen
-		v = trimQuotes(v)
 		out[k] = v
 	}
 	return out
 }
 
+// splitTopLevelComma divide por comas que no estén dentro de comillas o paréntesis
 func splitTopLevelComma(s string) []string {
-	// aquí basta con dividir por comas; si quisieras anidación, tocaría parser más robusto
-	parts := strings.Split(s, ",")
-	out := make([]string, 0, len(parts))
-	for _, p := range parts {
-		out = append(out, p)
+	var out []string
+	var quote byte
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			}
+		case c == ',' && depth == 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
 	}
-	return out
+	return append(out, s[start:])
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && ((s[0] == '\'' && s[len(s)-1] == '\'') || (s[0] == '"' && s[len(s)-1] == '"'))
 }
 
 func trimQuotes(s string) string {
-	if len(s) >= 2 {
-		if (s[0] == '\'' && s[len(s)-1] == '\'') || (s[0] == '"' && s[len(s)-1] == '"') {
-			return s[1 : len(s)-1]
-		}
+	if isQuoted(s) {
+		return s[1 : len(s)-1]
 	}
 	return s
 }
 
-// Reemplaza placeholders {{...}} dentro de strings
-func replacePlaceholders(input string, ctx MockContext) string {
-	re := regexp.MustCompile(`\{\{([^}]+)\}\}`)
-	return re.ReplaceAllStringFunc(input, func(match string) string {
-		key := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(match, "{{"), "}}"))
+// resolveArgs convierte los args crudos en valores finales:
+//   - 'literal' / "literal" -> literal sin comillas
+//   - body.x, query.y, ...  -> valor del request (stringificado)
+//   - cualquier otro valor  -> literal tal cual (p. ej. length: 16)
+func resolveArgs(args map[string]string, ctx MockContext) map[string]string {
+	if args == nil {
+		return nil
+	}
+	out := make(map[string]string, len(args))
+	for k, raw := range args {
+		if isQuoted(raw) {
+			out[k] = trimQuotes(raw)
+			continue
+		}
+		if val, ok := lookupValue(raw, ctx); ok {
+			out[k] = stringify(val)
+			continue
+		}
+		out[k] = raw
+	}
+	return out
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+
+// placeholderKey extrae "body.name" de "{{ body.name }}"
+func placeholderKey(match string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(match, "{{"), "}}"))
+}
 
-		// ---- Random (map extensible + args opcionales) ----
-		name, args := parseFuncCall(key)
-		if gen, ok := randomGenerators[name]; ok {
-			// args puede ser nil; los generadores esperan map[string]string (nil ok)
-			return gen(args)
+// resolvePlaceholder resuelve un placeholder dentro de un texto; match es el placeholder completo
+func resolvePlaceholder(match, key string, ctx MockContext) string {
+
+	// ---- Partials: {{> envelope}} ----
+	if strings.HasPrefix(key, ">") {
+		return stringify(callPartial(key, ctx))
+	}
+
+	// ---- Helpers estilo handlebars: {{join query.tag ','}} ----
+	if val, ok := callListHelper(key, ctx); ok {
+		return stringify(val)
+	}
+
+	// ---- Random / helpers (map extensible + args opcionales) ----
+	name, args := parseFuncCall(key)
+	if gen, ok := randomGenerators[name]; ok {
+		// args puede ser nil; los generadores esperan map[string]string (nil ok)
+		return gen(resolveArgs(args, ctx))
+	}
+	if helper, ok := encodingHelpers[name]; ok {
+		return helper(resolveArgs(args, ctx))
+	}
+	if newHash, ok := hmacHelpers[name]; ok {
+		signed, err := signHMAC(newHash, resolveArgs(args, ctx))
+		if err != nil {
+			ctx.fail(err)
+			return match
 		}
+		return signed
+	}
 
-		// ---- Path params ----
-		if strings.HasPrefix(name, "path.") {
-			field := strings.TrimPrefix(name, "path.")
-			return ctx.PathParams[field]
+	// ---- Valores del request (path., query., headers., body., dataset.) ----
+	if val, ok := lookupValue(key, ctx); ok {
+		if v, isString := val.(string); isString && strings.HasPrefix(key, "body.") {
+			// Si el valor del body trae otro placeholder, resolver en cascada
+			return renderText(v, ctx)
 		}
+		return stringify(val)
+	}
+
+	// si no coincide nada, regresamos el placeholder intacto
+	return match
+}
+
+// lookupValue resuelve una referencia al request (path., query., headers., body., request.), a un dataset
+// a una variable (vars., env.) o a un parámetro de partial (with.).
+// ok indica si el nombre pertenece a un namespace conocido, aunque el valor no exista.
+func lookupValue(name string, ctx MockContext) (any, bool) {
+	switch {
+	// ---- Path params ----
+	case strings.HasPrefix(name, "path."):
+		return ctx.PathParams[strings.TrimPrefix(name, "path.")], true
 
-		// ---- Query params ----
-		if strings.HasPrefix(name, "query.") {
-			field := strings.TrimPrefix(name, "query.")
-			return ctx.Query[field]
+	// ---- Query params (query.tag = primer valor, query.tag.[1] / query.tag[*] = multi-valor) ----
+	case strings.HasPrefix(name, "query."):
+		key, rest := ctx.splitQueryKey(strings.TrimPrefix(name, "query."))
+		return multiValue(ctx.Query[key], rest), true
+
+	// ---- Headers ----
+	case strings.HasPrefix(name, "headers."):
+		key, rest := ctx.splitHeaderKey(strings.TrimPrefix(name, "headers."))
+		return multiValue(ctx.headerValues(key), rest), true
+
+	// ---- Metadatos del request (request.method, request.body.x, ...) ----
+	case name == "request.body" || strings.HasPrefix(name, "request.body.") || strings.HasPrefix(name, "request.body["):
+		return lookupValue(strings.TrimPrefix(name, "request."), ctx)
+	case strings.HasPrefix(name, "request."):
+		field, ok := requestFields[strings.TrimPrefix(name, "request.")]
+		if !ok {
+			return nil, false
 		}
+		return field(ctx), true
+
+	// ---- Datasets (dataset.users.random, dataset.users.lookup(id: path.user_id).email) ----
+	case strings.HasPrefix(name, "dataset."):
+		return lookupDataset(strings.TrimPrefix(name, "dataset."), ctx), true
 
-		// ---- Headers ----
-		if strings.HasPrefix(name, "headers.") {
-			field := strings.TrimPrefix(name, "headers.")
-			return ctx.Headers[field]
+	// ---- Variables (vars.base_url) y entorno permitido (env.API_HOST) ----
+	case strings.HasPrefix(name, "vars."):
+		return lookupVariable(strings.TrimPrefix(name, "vars."), ctx), true
+	case isEnvKey(name):
+		return lookupEnv(strings.TrimPrefix(name, "env."))
+
+	// ---- Parámetros del partial (with.data, with.error.code) ----
+	case name == PartialParamsKey || strings.HasPrefix(name, PartialParamsKey+".") || strings.HasPrefix(name, PartialParamsKey+"["):
+		segs, err := parsePath(strings.TrimPrefix(name, PartialParamsKey))
+		if err != nil {
+			return nil, true
 		}
+		return evalPath(ctx.with, segs), true
 
-		// ---- Body (soporta subcampos body.a.b.c) ----
-		if strings.HasPrefix(name, "body.") {
-			field := strings.TrimPrefix(name, "body.")
-			parts := strings.Split(field, ".")
-			val := getNested(ctx.Body, parts)
-			switch v := val.(type) {
-			case string:
-				// Si el valor del body trae otro placeholder, resolver en cascada
-				return replacePlaceholders(v, ctx)
-			default:
-				if v == nil {
-					return ""
-				}
-				b, _ := json.Marshal(v)
-				return string(b)
-			}
+	// ---- Body (estilo JSONPath: body.a.b, body.items[0].sku, body.items[*].price.sum) ----
+	case name == "body" || strings.HasPrefix(name, "body.") || strings.HasPrefix(name, "body["):
+		segs, err := parsePath(strings.TrimPrefix(name, "body"))
+		if err != nil {
+			return nil, true
 		}
+		return evalPath(bodyRoot(ctx.Body, segs), segs), true
+	}
+	return nil, false
+}
+
+// headerValues busca el header tal cual llegó y, si no existe, en su forma canónica
+func (ctx MockContext) headerValues(key string) []string {
+	if values, ok := ctx.Headers[key]; ok {
+		return values
+	}
+	return ctx.Headers[textproto.CanonicalMIMEHeaderKey(key)]
+}
 
-		// si no coincide nada, regresamos el placeholder intacto
-		return match
+// splitQueryKey separa el query param del selector; page[size] o filter.status son
+// nombres válidos si llegaron así en la request
+func (ctx MockContext) splitQueryKey(field string) (string, string) {
+	return splitExistingKey(field, func(key string) bool {
+		_, ok := ctx.Query[key]
+		return ok
 	})
 }
 
-// Busca un valor anidado en map[string]any
-func getNested(m map[string]any, keys []string) any {
-	if len(keys) == 0 {
-		return nil
+func (ctx MockContext) splitHeaderKey(field string) (string, string) {
+	return splitExistingKey(field, func(key string) bool {
+		return ctx.headerValues(key) != nil
+	})
+}
+
+// splitExistingKey prefiere la llave más larga que exista (la completa primero) y, si
+// ninguna existe, corta como splitMultiValueKey
+func splitExistingKey(field string, exists func(string) bool) (string, string) {
+	for idx := len(field); idx > 0; idx-- {
+		if idx < len(field) && field[idx] != '.' && field[idx] != '[' {
+			continue
+		}
+		if exists(field[:idx]) {
+			return field[:idx], field[idx:]
+		}
 	}
-	val, ok := m[keys[0]]
-	if !ok {
+	return splitMultiValueKey(field)
+}
+
+// splitMultiValueKey separa "tag.[1]" en ("tag", ".[1]")
+func splitMultiValueKey(field string) (string, string) {
+	if idx := strings.IndexAny(field, ".["); idx >= 0 {
+		return field[:idx], field[idx:]
+	}
+	return field, ""
+}
+
+// multiValue devuelve el primer valor, o navega la lista completa si hay selector (.[1], [*], .length)
+func multiValue(values []string, rest string) any {
+	if rest == "" {
+		if len(values) == 0 {
+			return nil
+		}
+		return values[0]
+	}
+	segs, err := parsePath(rest)
+	if err != nil {
 		return nil
 	}
-	if len(keys) == 1 {
-		return val
+	list := make([]any, len(values))
+	for i, v := range values {
+		list[i] = v
+	}
+	return evalPath(list, segs)
+}
+
+// RawBodyKey es la llave bajo la que se guarda un body que no es un objeto JSON
+// (arrays, escalares o texto plano).
+const RawBodyKey = "raw"
+
+// bodyRoot permite navegar un body array ({"raw": [...]}) como body[0] / body.0
+// sin tener que escribir body.raw[0].
+func bodyRoot(body map[string]any, segs []pathSegment) any {
+	raw, wrapped := body[RawBodyKey]
+	if !wrapped || len(body) != 1 {
+		return body
 	}
-	if sub, ok := val.(map[string]any); ok {
-		return getNested(sub, keys[1:])
+	if len(segs) > 0 && segs[0].kind == segField && segs[0].field == RawBodyKey {
+		return body
 	}
-	return nil
+	return raw
 }
 
-// Resuelve recursivamente maps y arrays
-func resolvePlaceholdersDeep(node any, ctx MockContext) any {
-	switch v := node.(type) {
+// stringify convierte un valor resuelto a texto; objetos y arrays van como JSON
+func stringify(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
 	case string:
-		return replacePlaceholders(v, ctx)
-	case map[string]any:
-		out := make(map[string]any, len(v))
-		for k, val := range v {
-			out[k] = resolvePlaceholdersDeep(val, ctx)
-		}
-		return out
-	case []any:
-		out := make([]any, len(v))
-		for i, val := range v {
-			out[i] = resolvePlaceholdersDeep(val, ctx)
+		return v
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+var singlePlaceholderRe = regexp.MustCompile(`^\{\{([^}]+)\}\}$`)
+
+// resolveValue resuelve un string que es exactamente un placeholder conservando su tipo;
+// ok=false cuando no es un valor (helpers, random, ...) y debe tratarse como texto.
+func resolveValue(key string, ctx MockContext) (any, bool) {
+	if strings.HasPrefix(key, ">") {
+		// el partial completo conserva su forma (objeto, array, ...)
+		return callPartial(key, ctx), true
+	}
+	val, ok := lookupValue(key, ctx)
+	if !ok {
+		return nil, false
+	}
+	switch v := val.(type) {
+	case nil:
+		return "", true
+	case string:
+		if strings.HasPrefix(key, "body.") {
+			// Si el valor del body trae otro placeholder, resolver en cascada
+			return renderText(v, ctx), true
 		}
-		return out
+		return v, true
 	default:
-		return v
+		return v, true
 	}
 }
 
 type PlaceholderController struct {
+	datasets  DatasetSource
+	partials  PartialSource
+	variables VariableSource
 }
 
-func NewPlaceholderController() *PlaceholderController {
-	return &PlaceholderController{}
+func NewPlaceholderController(datasets DatasetSource, partials PartialSource, variables VariableSource) *PlaceholderController {
+	return &PlaceholderController{
+		datasets:  datasets,
+		partials:  partials,
+		variables: variables,
+	}
 }
 
-func (c *PlaceholderController) Resolve(ctx MockContext, input map[string]any) (any, error) {
+// Render resuelve un template ya compilado; los templates estáticos se devuelven sin tocar
+func (c *PlaceholderController) Render(ctx MockContext, tpl *Template) (any, error) {
 
-	resolved := resolvePlaceholdersDeep(input, ctx)
+	if tpl.static != nil {
+		return tpl.root.render(ctx), nil
+	}
+
+	ctx.datasets = c.datasets
+	ctx.partials = c.partials
+	if c.variables != nil {
+		ctx.vars = c.variables.Variables(ctx.goContext(), ctx.Request.PrototypeGroup)
+	}
+	ctx.state = &resolveState{}
+
+	resolved := tpl.root.render(ctx)
+	if ctx.state.err != nil {
+		return nil, ctx.state.err
+	}
 	return resolved, nil
 }
@@ -0,0 +1,153 @@
+package placeholder
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// DatasetSource da acceso a los datasets subidos por /v1/datasets
+type DatasetSource interface {
+	Records(ctx context.Context, name string) ([]map[string]any, bool)
+}
+
+// RecordNotFoundError se produce cuando {{dataset.x.lookup(...)}} no encuentra registro;
+// el mock responde 404 en lugar de un body con campos vacíos.
+type RecordNotFoundError struct {
+	Dataset string
+	Filter  map[string]string
+}
+
+func (e *RecordNotFoundError) Error() string {
+	return fmt.Sprintf("no record in dataset %s matches %v", e.Dataset, e.Filter)
+}
+
+// ==== {{dataset.users.random}}, {{dataset.users.lookup(id: path.user_id).email}}, {{dataset.users.page(page: query.page, size: 10)}} ====
+
+// datasetSelectors operan sobre los registros y devuelven el nuevo valor actual
+var datasetSelectors = map[string]func(ctx MockContext, dataset string, records []any, args map[string]string) any{
+	"random": func(_ MockContext, _ string, records []any, _ map[string]string) any {
+		if len(records) == 0 {
+			return nil
+		}
+		return records[rand.Intn(len(records))]
+	},
+	"lookup": func(ctx MockContext, dataset string, records []any, args map[string]string) any {
+		for _, record := range records {
+			if recordMatches(record, args) {
+				return record
+			}
+		}
+		ctx.fail(&RecordNotFoundError{Dataset: dataset, Filter: args})
+		return nil
+	},
+	"page": func(_ MockContext, _ string, records []any, args map[string]string) any {
+		page, err := strconv.Atoi(getArgOr(args, "page", "1"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		size, err := strconv.Atoi(getArgOr(args, "size", "10"))
+		if err != nil || size < 1 {
+			size = 10
+		}
+		start := (page - 1) * size
+		if start > len(records) {
+			start = len(records)
+		}
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+		return records[start:end]
+	},
+}
+
+func recordMatches(record any, filter map[string]string) bool {
+	m, ok := record.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, want := range filter {
+		if stringify(m[key]) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupDataset resuelve "users.lookup(id: path.user_id).email"
+func lookupDataset(expr string, ctx MockContext) any {
+	name, rest := splitMultiValueKey(expr)
+	if ctx.datasets == nil {
+		return nil
+	}
+	records, ok := ctx.datasets.Records(ctx.goContext(), name)
+	if !ok {
+		return nil
+	}
+
+	list := make([]any, len(records))
+	for i, r := range records {
+		list[i] = r
+	}
+
+	// selector opcional: .random / .lookup(...) / .page(...)
+	var current any = list
+	if strings.HasPrefix(rest, ".") {
+		selector, _ := readIdentifier(rest, 1)
+		call := selector
+		args := ""
+		if idx := strings.Index(selector, "("); idx >= 0 {
+			call = selector[:idx]
+		}
+		if fn, isSelector := datasetSelectors[call]; isSelector {
+			consumed := 1 + len(call)
+			if strings.HasPrefix(rest[consumed:], "(") {
+				end := matchingParen(rest, consumed)
+				if end < 0 {
+					return nil
+				}
+				args = rest[consumed+1 : end]
+				consumed = end + 1
+			}
+			current = fn(ctx, name, list, resolveArgs(parseArgs(args), ctx))
+			rest = rest[consumed:]
+		}
+	}
+
+	if rest == "" {
+		return current
+	}
+	segs, err := parsePath(rest)
+	if err != nil {
+		return nil
+	}
+	return evalPath(current, segs)
+}
+
+// matchingParen devuelve el índice del ')' que cierra el '(' en open, respetando comillas
+func matchingParen(expr string, open int) int {
+	var quote byte
+	depth := 0
+	for i := open; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
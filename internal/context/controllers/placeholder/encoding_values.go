@@ -0,0 +1,104 @@
+package placeholder
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/url"
+
+	"mocky/internal/core/settings"
+)
+
+// encodingHelpers reciben el valor a transformar en el arg "value".
+// El valor puede ser un literal ('abc') o una referencia al request (body.payload).
+var encodingHelpers = map[string]func(args map[string]string) string{
+	// === Base64 ===
+	"encode.Base64": func(args map[string]string) string {
+		return base64.StdEncoding.EncodeToString([]byte(args["value"]))
+	},
+	"decode.Base64": func(args map[string]string) string {
+		b, err := base64.StdEncoding.DecodeString(args["value"])
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	},
+	"encode.Base64URL": func(args map[string]string) string { // sin padding, apto para JWT
+		return base64.RawURLEncoding.EncodeToString([]byte(args["value"]))
+	},
+	"decode.Base64URL": func(args map[string]string) string {
+		b, err := base64.RawURLEncoding.DecodeString(args["value"])
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	},
+
+	// === Hex ===
+	"encode.Hex": func(args map[string]string) string {
+		return hex.EncodeToString([]byte(args["value"]))
+	},
+	"decode.Hex": func(args map[string]string) string {
+		b, err := hex.DecodeString(args["value"])
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	},
+
+	// === URL ===
+	"encode.URL": func(args map[string]string) string {
+		return url.QueryEscape(args["value"])
+	},
+	"decode.URL": func(args map[string]string) string {
+		s, err := url.QueryUnescape(args["value"])
+		if err != nil {
+			return ""
+		}
+		return s
+	},
+
+	// === Hashes (encoding opcional: hex | base64 | base64url) ===
+	"hash.SHA256": func(args map[string]string) string { return digest(sha256.New(), args) },
+	"hash.SHA1":   func(args map[string]string) string { return digest(sha1.New(), args) },
+	"hash.MD5":    func(args map[string]string) string { return digest(md5.New(), args) },
+}
+
+// ErrMissingHMACSecret evita firmar con una llave vacía cuando HMAC_SECRET no está configurado
+var ErrMissingHMACSecret = errors.New("HMAC_SECRET is not configured; hmac helpers cannot sign")
+
+// hmacHelpers firman el arg "value" con el secreto configurado en HMAC_SECRET
+var hmacHelpers = map[string]func() hash.Hash{
+	"hmac.SHA256": sha256.New,
+	"hmac.SHA1":   sha1.New,
+}
+
+func signHMAC(newHash func() hash.Hash, args map[string]string) (string, error) {
+	secret := settings.Settings.HMAC_SECRET
+	if secret == "" {
+		return "", ErrMissingHMACSecret
+	}
+	return digest(hmac.New(newHash, []byte(secret)), args), nil
+}
+
+func digest(h hash.Hash, args map[string]string) string {
+	h.Write([]byte(args["value"]))
+	return encodeBytes(h.Sum(nil), getArgOr(args, "encoding", "hex"))
+}
+
+// encodeBytes serializa bytes binarios según el encoding pedido (default hex)
+func encodeBytes(b []byte, encoding string) string {
+	switch encoding {
+	case "base64":
+		return base64.StdEncoding.EncodeToString(b)
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(b)
+	default:
+		return hex.EncodeToString(b)
+	}
+}
@@ -0,0 +1,463 @@
+package placeholder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==== Acceso estilo JSONPath: body.items.0.sku, body.items[*].price, body.items[?(@.qty > 1)].sku ====
+
+type segmentKind int
+
+const (
+	segField segmentKind = iota
+	segIndex
+	segWildcard
+	segFilter
+)
+
+type pathSegment struct {
+	kind   segmentKind
+	field  string
+	index  int
+	filter filterExpr
+}
+
+// Agregados que se aplican sobre arrays (o sobre el resultado de un wildcard/filtro)
+var pathAggregates = map[string]func(items []any) any{
+	"length": func(items []any) any { return len(items) },
+	"sum": func(items []any) any {
+		total := 0.0
+		for _, it := range items {
+			if n, ok := toFloat(it); ok {
+				total += n
+			}
+		}
+		return total
+	},
+}
+
+// parsePath convierte "items[0].sku", ".items.*.price" o "[?(@.qty > 1)]" en segmentos
+func parsePath(expr string) ([]pathSegment, error) {
+	var segs []pathSegment
+	i := 0
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			i++
+			if i < len(expr) && expr[i] == '[' {
+				// estilo handlebars: tags.[1]
+				continue
+			}
+			name, next := readIdentifier(expr, i)
+			if name == "" {
+				return nil, fmt.Errorf("empty segment at position %d in %q", i, expr)
+			}
+			segs = append(segs, fieldSegment(name))
+			i = next
+		case '[':
+			end := matchingBracket(expr, i)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in %q", expr)
+			}
+			seg, err := parseBracket(strings.TrimSpace(expr[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+			i = end + 1
+		default:
+			name, next := readIdentifier(expr, i)
+			segs = append(segs, fieldSegment(name))
+			i = next
+		}
+	}
+	return segs, nil
+}
+
+func readIdentifier(expr string, start int) (string, int) {
+	i := start
+	for i < len(expr) && expr[i] != '.' && expr[i] != '[' {
+		i++
+	}
+	return strings.TrimSpace(expr[start:i]), i
+}
+
+func fieldSegment(name string) pathSegment {
+	if name == "*" {
+		return pathSegment{kind: segWildcard}
+	}
+	// length() y sum() se aceptan también con paréntesis
+	return pathSegment{kind: segField, field: strings.TrimSuffix(name, "()")}
+}
+
+// matchingBracket devuelve el índice del ']' que cierra el '[' en open, respetando comillas
+func matchingBracket(expr string, open int) int {
+	var quote byte
+	depth := 0
+	for i := open; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseBracket(inner string) (pathSegment, error) {
+	switch {
+	case inner == "*":
+		return pathSegment{kind: segWildcard}, nil
+	case strings.HasPrefix(inner, "?"):
+		raw := strings.TrimSpace(strings.TrimPrefix(inner, "?"))
+		if strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")") {
+			raw = raw[1 : len(raw)-1]
+		}
+		f, err := parseFilter(raw)
+		if err != nil {
+			return pathSegment{}, err
+		}
+		return pathSegment{kind: segFilter, filter: f}, nil
+	case isQuoted(inner):
+		return pathSegment{kind: segField, field: trimQuotes(inner)}, nil
+	}
+	if n, err := strconv.Atoi(inner); err == nil {
+		return pathSegment{kind: segIndex, index: n}, nil
+	}
+	return pathSegment{kind: segField, field: inner}, nil
+}
+
+// evalPath recorre root siguiendo los segmentos. Si hubo wildcard o filtro el resultado es []any.
+func evalPath(root any, segs []pathSegment) any {
+	current := []any{root}
+	multi := false
+
+	for _, seg := range segs {
+		if seg.kind == segField {
+			if agg, ok := pathAggregates[seg.field]; ok {
+				if items, isList := aggregateInput(current, multi); isList {
+					current = []any{agg(items)}
+					multi = false
+					continue
+				}
+			}
+		}
+
+		next := make([]any, 0, len(current))
+		for _, v := range current {
+			next = append(next, stepInto(v, seg)...)
+		}
+		if seg.kind == segWildcard || seg.kind == segFilter {
+			multi = true
+		}
+		current = next
+	}
+
+	if multi {
+		return current
+	}
+	if len(current) == 0 {
+		return nil
+	}
+	return current[0]
+}
+
+func aggregateInput(current []any, multi bool) ([]any, bool) {
+	if multi {
+		return current, true
+	}
+	if len(current) == 1 {
+		if arr, ok := current[0].([]any); ok {
+			return arr, true
+		}
+	}
+	return nil, false
+}
+
+func stepInto(v any, seg pathSegment) []any {
+	switch seg.kind {
+	case segField:
+		switch node := v.(type) {
+		case map[string]any:
+			if val, ok := node[seg.field]; ok {
+				return []any{val}
+			}
+		case []any:
+			if n, err := strconv.Atoi(seg.field); err == nil {
+				return indexInto(node, n)
+			}
+		}
+	case segIndex:
+		if arr, ok := v.([]any); ok {
+			return indexInto(arr, seg.index)
+		}
+	case segWildcard:
+		switch node := v.(type) {
+		case []any:
+			return node
+		case map[string]any:
+			keys := make([]string, 0, len(node))
+			for k := range node {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := make([]any, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, node[k])
+			}
+			return out
+		}
+	case segFilter:
+		if arr, ok := v.([]any); ok {
+			out := make([]any, 0, len(arr))
+			for _, item := range arr {
+				if seg.filter.matches(item) {
+					out = append(out, item)
+				}
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+// indexInto soporta índices negativos (-1 = último)
+func indexInto(arr []any, n int) []any {
+	if n < 0 {
+		n += len(arr)
+	}
+	if n < 0 || n >= len(arr) {
+		return nil
+	}
+	return []any{arr[n]}
+}
+
+// ==== Filtros: @.qty > 1 && @.status == 'paid' || @.vip ====
+
+type filterExpr struct {
+	// OR de ANDs
+	anyOf [][]filterTerm
+}
+
+type filterTerm struct {
+	left  filterOperand
+	op    string
+	right filterOperand
+}
+
+type filterOperand struct {
+	path    []pathSegment
+	isPath  bool
+	literal any
+}
+
+var filterOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+func parseFilter(raw string) (filterExpr, error) {
+	var f filterExpr
+	for _, orPart := range splitOutsideQuotes(raw, "||") {
+		var terms []filterTerm
+		for _, andPart := range splitOutsideQuotes(orPart, "&&") {
+			t, err := parseFilterTerm(strings.TrimSpace(andPart))
+			if err != nil {
+				return f, err
+			}
+			terms = append(terms, t)
+		}
+		f.anyOf = append(f.anyOf, terms)
+	}
+	return f, nil
+}
+
+func parseFilterTerm(raw string) (filterTerm, error) {
+	for _, op := range filterOperators {
+		if idx := indexOutsideQuotes(raw, op); idx >= 0 {
+			left, err := parseOperand(strings.TrimSpace(raw[:idx]))
+			if err != nil {
+				return filterTerm{}, err
+			}
+			right, err := parseOperand(strings.TrimSpace(raw[idx+len(op):]))
+			if err != nil {
+				return filterTerm{}, err
+			}
+			return filterTerm{left: left, op: op, right: right}, nil
+		}
+	}
+	// sin operador: se evalúa como "truthy"
+	left, err := parseOperand(raw)
+	if err != nil {
+		return filterTerm{}, err
+	}
+	return filterTerm{left: left}, nil
+}
+
+func parseOperand(raw string) (filterOperand, error) {
+	if strings.HasPrefix(raw, "@") {
+		segs, err := parsePath(raw[1:])
+		if err != nil {
+			return filterOperand{}, err
+		}
+		return filterOperand{path: segs, isPath: true}, nil
+	}
+	return filterOperand{literal: parseLiteral(raw)}, nil
+}
+
+func parseLiteral(raw string) any {
+	switch {
+	case isQuoted(raw):
+		return trimQuotes(raw)
+	case raw == "true":
+		return true
+	case raw == "false":
+		return false
+	case raw == "null":
+		return nil
+	}
+	if n, err := strconv.ParseFloat(raw, 64); err == nil {
+		return n
+	}
+	return raw
+}
+
+func (o filterOperand) value(item any) any {
+	if o.isPath {
+		return evalPath(item, o.path)
+	}
+	return o.literal
+}
+
+func (f filterExpr) matches(item any) bool {
+	for _, terms := range f.anyOf {
+		all := true
+		for _, t := range terms {
+			if !t.matches(item) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func (t filterTerm) matches(item any) bool {
+	left := t.left.value(item)
+	if t.op == "" {
+		return truthy(left)
+	}
+	right := t.right.value(item)
+
+	if ln, ok := toFloat(left); ok {
+		if rn, ok := toFloat(right); ok {
+			return compareOrdered(ln, rn, t.op)
+		}
+	}
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok {
+			return compareOrdered(ls, rs, t.op)
+		}
+	}
+	switch t.op {
+	case "==":
+		return fmt.Sprint(left) == fmt.Sprint(right)
+	case "!=":
+		return fmt.Sprint(left) != fmt.Sprint(right)
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](a, b T, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+func truthy(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case string:
+		return x != ""
+	case float64:
+		return x != 0
+	case []any:
+		return len(x) > 0
+	}
+	return true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func splitOutsideQuotes(s, sep string) []string {
+	var out []string
+	for {
+		idx := indexOutsideQuotes(s, sep)
+		if idx < 0 {
+			return append(out, s)
+		}
+		out = append(out, s[:idx])
+		s = s[idx+len(sep):]
+	}
+}
+
+func indexOutsideQuotes(s, sub string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(s[i:], sub):
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,89 @@
+package placeholder
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONPath(t *testing.T) {
+	var body map[string]any
+	raw := `{
+		"items": [
+			{"sku": "A1", "qty": 1, "price": 10.5, "status": "paid", "vip": false},
+			{"sku": "B2", "qty": 3, "price": 4, "status": "pending", "vip": true},
+			{"sku": "C3", "qty": 2, "price": 1.5, "status": "paid"}
+		],
+		"customer": {"name": "Ana", "tags": ["a", "b"], "a.b": "dotted"},
+		"empty": []
+	}`
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+	ctx := MockContext{Body: body}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		// Acceso directo
+		{"body.customer.name", `"Ana"`},
+		{"body.items[0].sku", `"A1"`},
+		{"body.items.1.sku", `"B2"`},
+		{"body.items[-1].sku", `"C3"`},
+		{"body.customer.tags.[1]", `"b"`},
+		{`body.customer["a.b"]`, `"dotted"`},
+		{"body.items[9].sku", `null`},
+		{"body.missing.name", `null`},
+
+		// Wildcards
+		{"body.items[*].sku", `["A1","B2","C3"]`},
+		{"body.items.*.qty", `[1,3,2]`},
+		{"body.customer.tags[*]", `["a","b"]`},
+
+		// Filtros
+		{"body.items[?(@.qty > 1)].sku", `["B2","C3"]`},
+		{"body.items[?(@.qty >= 2)].sku", `["B2","C3"]`},
+		{"body.items[?(@.qty <= 1)].sku", `["A1"]`},
+		{"body.items[?(@.status == 'paid')].sku", `["A1","C3"]`},
+		{"body.items[?(@.status != 'paid')].sku", `["B2"]`},
+		{"body.items[?(@.status == 'paid' && @.qty > 1)].sku", `["C3"]`},
+		{"body.items[?(@.qty == 1 || @.vip)].sku", `["A1","B2"]`},
+		{"body.items[?(@.vip)].sku", `["B2"]`},
+		{"body.items[?(@.status == 'refunded')].sku", `[]`},
+		{"body.items[?(@.sku == 'a || b')].sku", `[]`},
+
+		// Agregados
+		{"body.items.length", `3`},
+		{"body.items.length()", `3`},
+		{"body.customer.tags.length", `2`},
+		{"body.empty.length", `0`},
+		{"body.items[*].price.sum", `16`},
+		{"body.items[*].price.sum()", `16`},
+		{"body.items[?(@.status == 'paid')].price.sum", `12`},
+		{"body.items[?(@.status == 'paid')].length", `2`},
+		{"body.items[?(@.qty > 5)].price.sum", `0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			val, ok := lookupValue(tt.path, ctx)
+			if !ok {
+				t.Fatalf("%s is not a known namespace", tt.path)
+			}
+			got, _ := json.Marshal(val)
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	for _, path := range []string{"items[0", "items..sku", "items[?(@.qty > 1)"} {
+		t.Run(path, func(t *testing.T) {
+			if _, err := parsePath(path); err == nil {
+				t.Fatalf("expected a parse error for %q", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package placeholder
+
+import (
+	"strings"
+)
+
+// listHelpers usan sintaxis estilo handlebars: {{join query.tag ','}}.
+// Cada arg puede ser un literal entre comillas o una referencia al request.
+var listHelpers = map[string]func(args []any) any{
+	"join": func(args []any) any {
+		if len(args) == 0 {
+			return ""
+		}
+		sep := ","
+		if len(args) > 1 {
+			sep = stringify(args[1])
+		}
+		switch v := args[0].(type) {
+		case []any:
+			parts := make([]string, len(v))
+			for i, item := range v {
+				parts[i] = stringify(item)
+			}
+			return strings.Join(parts, sep)
+		case []string:
+			return strings.Join(v, sep)
+		default:
+			return stringify(v)
+		}
+	},
+}
+
+// callListHelper evalúa "helper arg1 arg2" si el primer token es un helper registrado
+func callListHelper(expr string, ctx MockContext) (any, bool) {
+	tokens := splitOutsideQuotesFields(expr)
+	if len(tokens) == 0 {
+		return nil, false
+	}
+	helper, ok := listHelpers[tokens[0]]
+	if !ok {
+		return nil, false
+	}
+	args := make([]any, 0, len(tokens)-1)
+	for _, tok := range tokens[1:] {
+		args = append(args, resolveHelperArg(tok, ctx))
+	}
+	return helper(args), true
+}
+
+// resolveHelperArg conserva el tipo del valor (p. ej. la lista completa de query.tag)
+func resolveHelperArg(tok string, ctx MockContext) any {
+	if isQuoted(tok) {
+		return trimQuotes(tok)
+	}
+	// query.tag y headers.Accept sin selector pasan la lista completa
+	if key, rest := ctx.splitQueryKey(strings.TrimPrefix(tok, "query.")); strings.HasPrefix(tok, "query.") && rest == "" {
+		return ctx.Query[key]
+	}
+	if key, rest := ctx.splitHeaderKey(strings.TrimPrefix(tok, "headers.")); strings.HasPrefix(tok, "headers.") && rest == "" {
+		return ctx.headerValues(key)
+	}
+	if val, ok := lookupValue(tok, ctx); ok {
+		return val
+	}
+	return tok
+}
+
+// splitOutsideQuotesFields divide por espacios que no estén entre comillas
+func splitOutsideQuotesFields(s string) []string {
+	var out []string
+	var quote byte
+	start := -1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			if start < 0 {
+				start = i
+			}
+			continue
+		case c == ' ' || c == '\t':
+			if start >= 0 {
+				out = append(out, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		out = append(out, s[start:])
+	}
+	return out
+}
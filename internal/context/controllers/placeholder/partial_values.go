@@ -0,0 +1,122 @@
+package placeholder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PartialSource da acceso a los partials registrados en /v1/partials
+type PartialSource interface {
+	Partial(ctx context.Context, name string) (any, bool)
+}
+
+const (
+	// PartialKey referencia un partial desde el body: {"$partial": "envelope", "with": {...}}
+	PartialKey = "$partial"
+	// PartialParamsKey son los parámetros del partial, accesibles como {{with.x}}
+	PartialParamsKey = "with"
+
+	// evita ciclos entre partials que se incluyen entre sí
+	maxPartialDepth = 10
+)
+
+// ==== {"$partial": "envelope", "with": {"data": "{{body}}"}} ====
+
+// renderPartialNode resuelve un objeto con "$partial". Los "with" se resuelven en el
+// contexto de quien llama y las demás llaves del objeto se mezclan sobre el resultado.
+func renderPartialNode(n partialNode, ctx MockContext) any {
+	name, ok := n.name.(string)
+	if !ok {
+		ctx.fail(fmt.Errorf("%s must be a string", PartialKey))
+		return nil
+	}
+
+	params := map[string]any{}
+	if n.with != nil {
+		if with, ok := n.with.render(ctx).(map[string]any); ok {
+			params = with
+		}
+	}
+
+	rendered := renderPartial(name, params, ctx)
+
+	if len(n.extra) == 0 {
+		return rendered
+	}
+	if obj, ok := rendered.(map[string]any); ok {
+		// un partial estático devuelve el body registrado; se copia antes de mezclar
+		merged := make(map[string]any, len(obj)+len(n.extra))
+		for k, v := range obj {
+			merged[k] = v
+		}
+		for k, field := range n.extra {
+			merged[k] = field.render(ctx)
+		}
+		return merged
+	}
+	return rendered
+}
+
+// ==== {{> envelope}}, {{> error code='NOT_FOUND' status=404}} ====
+
+// callPartial evalúa "> name key=value ..." (sintaxis de partials de handlebars)
+func callPartial(expr string, ctx MockContext) any {
+	tokens := splitOutsideQuotesFields(strings.TrimSpace(strings.TrimPrefix(expr, ">")))
+	if len(tokens) == 0 {
+		ctx.fail(fmt.Errorf("partial name is required in {{%s}}", expr))
+		return nil
+	}
+
+	params := map[string]any{}
+	for _, tok := range tokens[1:] {
+		kv := strings.SplitN(tok, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[kv[0]] = resolvePartialArg(kv[1], ctx)
+	}
+	return renderPartial(tokens[0], params, ctx)
+}
+
+// resolvePartialArg conserva el tipo: 'texto', body.user (objeto), 404, true
+func resolvePartialArg(raw string, ctx MockContext) any {
+	if isQuoted(raw) {
+		return trimQuotes(raw)
+	}
+	if val, ok := lookupValue(raw, ctx); ok {
+		return val
+	}
+	return parseLiteral(raw)
+}
+
+// renderPartial resuelve el body del partial con sus parámetros. Los parámetros del
+// partial que lo incluye se heredan, como en handlebars.
+func renderPartial(name string, params map[string]any, ctx MockContext) any {
+	if ctx.partialDepth >= maxPartialDepth {
+		ctx.fail(fmt.Errorf("partial %s exceeds max depth %d (cyclic include?)", name, maxPartialDepth))
+		return nil
+	}
+	if ctx.partials == nil {
+		ctx.fail(fmt.Errorf("partial %s not found", name))
+		return nil
+	}
+	body, ok := ctx.partials.Partial(ctx.goContext(), name)
+	if !ok {
+		ctx.fail(fmt.Errorf("partial %s not found", name))
+		return nil
+	}
+
+	with := make(map[string]any, len(ctx.with)+len(params))
+	for k, v := range ctx.with {
+		with[k] = v
+	}
+	for k, v := range params {
+		with[k] = v
+	}
+
+	child := ctx
+	child.with = with
+	child.partialDepth++
+	return compileNode(body).render(child)
+}
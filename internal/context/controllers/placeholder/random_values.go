@@ -1,6 +1,7 @@
 package placeholder
 
 import (
+	crand "crypto/rand"
 	"math/rand"
 	"strconv"
 	"time"
@@ -8,6 +9,9 @@ import (
 	"github.com/bxcodec/faker/v4"
 )
 
+// tope de random.Bytes, para que un length enorme no reserve memoria sin límite
+const maxRandomBytes = 1024
+
 var randomGenerators = map[string]func(args map[string]string) string{
 	// === Identificadores / seguridad ===
 	"random.UUID": func(args map[string]string) string { // uuid con guiones
@@ -19,6 +23,18 @@ var randomGenerators = map[string]func(args map[string]string) string{
 	"random.JWT": func(args map[string]string) string {
 		return faker.Jwt()
 	},
+	"random.Bytes": func(args map[string]string) string { // length bytes, encoding hex | base64 | base64url
+		n, err := strconv.Atoi(getArgOr(args, "length", "16"))
+		if err != nil || n <= 0 {
+			n = 16
+		}
+		if n > maxRandomBytes {
+			n = maxRandomBytes
+		}
+		b := make([]byte, n)
+		_, _ = crand.Read(b)
+		return encodeBytes(b, getArgOr(args, "encoding", "hex"))
+	},
 
 	// === Persona / nombres ===
 	"random.Name":      func(args map[string]string) string { return faker.Name() },
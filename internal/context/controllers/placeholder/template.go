@@ -0,0 +1,216 @@
+package placeholder
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ==== Templates compilados ====
+
+// Template es el body de respuesta de un prototype ya parseado: cada string se separa una
+// sola vez en texto y placeholders, y los subárboles sin placeholders se reutilizan tal cual.
+// Es inmutable; se comparte entre requests.
+type Template struct {
+	root templateNode
+	// body ya serializado cuando no tiene placeholders ni partials
+	static []byte
+}
+
+// CompileTemplate parsea el body de respuesta
+func CompileTemplate(body any) *Template {
+	tpl := &Template{root: compileNode(body)}
+	if literal, ok := tpl.root.(literalNode); ok {
+		if rendered, err := json.Marshal(literal.value); err == nil {
+			tpl.static = rendered
+		}
+	}
+	return tpl
+}
+
+// Static devuelve el body ya serializado cuando el template no tiene nada que resolver
+func (t *Template) Static() ([]byte, bool) {
+	return t.static, t.static != nil
+}
+
+type templateNode interface {
+	render(ctx MockContext) any
+}
+
+// literalNode: valor sin placeholders
+type literalNode struct {
+	value any
+}
+
+// valueNode: el string es exactamente un placeholder y conserva el tipo del valor
+type valueNode struct {
+	text string
+	key  string
+}
+
+// textNode: texto con placeholders intercalados
+type textNode struct {
+	parts []textPart
+}
+
+// textPart es texto literal o, si key no está vacío, un placeholder (match es el original)
+type textPart struct {
+	literal string
+	key     string
+	match   string
+}
+
+type objectNode struct {
+	fields map[string]templateNode
+}
+
+type arrayNode struct {
+	items []templateNode
+}
+
+// partialNode: {"$partial": "...", "with": {...}}; "with" y las demás llaves se compilan
+// con el template, pero el body del partial se lee en cada request porque puede cambiar
+// después de crear el prototype
+type partialNode struct {
+	name  any
+	with  templateNode
+	extra map[string]templateNode
+}
+
+func compileNode(node any) templateNode {
+	switch v := node.(type) {
+	case string:
+		return compileString(v)
+	case map[string]any:
+		if _, isPartial := v[PartialKey]; isPartial {
+			return compilePartial(v)
+		}
+		fields := make(map[string]templateNode, len(v))
+		static := true
+		for k, val := range v {
+			fields[k] = compileNode(val)
+			if _, ok := fields[k].(literalNode); !ok {
+				static = false
+			}
+		}
+		if static {
+			return literalNode{value: v}
+		}
+		return objectNode{fields: fields}
+	case []any:
+		items := make([]templateNode, len(v))
+		static := true
+		for i, val := range v {
+			items[i] = compileNode(val)
+			if _, ok := items[i].(literalNode); !ok {
+				static = false
+			}
+		}
+		if static {
+			return literalNode{value: v}
+		}
+		return arrayNode{items: items}
+	default:
+		return literalNode{value: v}
+	}
+}
+
+func compileString(s string) templateNode {
+	if m := singlePlaceholderRe.FindStringSubmatch(s); m != nil {
+		return valueNode{text: s, key: strings.TrimSpace(m[1])}
+	}
+	text := compileText(s)
+	if len(text.parts) == 0 {
+		return literalNode{value: s}
+	}
+	return text
+}
+
+// compileText separa el string en texto y placeholders; sin placeholders no hay partes
+func compileText(s string) textNode {
+	locations := placeholderRe.FindAllStringIndex(s, -1)
+	if len(locations) == 0 {
+		return textNode{}
+	}
+
+	var parts []textPart
+	last := 0
+	for _, loc := range locations {
+		if loc[0] > last {
+			parts = append(parts, textPart{literal: s[last:loc[0]]})
+		}
+		match := s[loc[0]:loc[1]]
+		parts = append(parts, textPart{key: placeholderKey(match), match: match})
+		last = loc[1]
+	}
+	if last < len(s) {
+		parts = append(parts, textPart{literal: s[last:]})
+	}
+	return textNode{parts: parts}
+}
+
+func compilePartial(v map[string]any) partialNode {
+	n := partialNode{name: v[PartialKey], extra: map[string]templateNode{}}
+	for k, val := range v {
+		switch k {
+		case PartialKey:
+		case PartialParamsKey:
+			n.with = compileNode(val)
+		default:
+			n.extra[k] = compileNode(val)
+		}
+	}
+	return n
+}
+
+// renderText resuelve los placeholders de un string que llega en tiempo de request
+// (p. ej. un valor del body que trae otro placeholder); el resultado siempre es texto
+func renderText(s string, ctx MockContext) string {
+	text := compileText(s)
+	if len(text.parts) == 0 {
+		return s
+	}
+	return text.render(ctx).(string)
+}
+
+func (n literalNode) render(_ MockContext) any {
+	return n.value
+}
+
+func (n valueNode) render(ctx MockContext) any {
+	if val, ok := resolveValue(n.key, ctx); ok {
+		return val
+	}
+	return resolvePlaceholder(n.text, n.key, ctx)
+}
+
+func (n textNode) render(ctx MockContext) any {
+	var b strings.Builder
+	for _, part := range n.parts {
+		if part.key == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+		b.WriteString(resolvePlaceholder(part.match, part.key, ctx))
+	}
+	return b.String()
+}
+
+func (n objectNode) render(ctx MockContext) any {
+	out := make(map[string]any, len(n.fields))
+	for k, field := range n.fields {
+		out[k] = field.render(ctx)
+	}
+	return out
+}
+
+func (n arrayNode) render(ctx MockContext) any {
+	out := make([]any, len(n.items))
+	for i, item := range n.items {
+		out[i] = item.render(ctx)
+	}
+	return out
+}
+
+func (n partialNode) render(ctx MockContext) any {
+	return renderPartialNode(n, ctx)
+}
@@ -0,0 +1,89 @@
+package placeholder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type partialsStub map[string]any
+
+func (p partialsStub) Partial(_ context.Context, name string) (any, bool) {
+	body, ok := p[name]
+	return body, ok
+}
+
+func renderJSON(t *testing.T, partials PartialSource, ctx MockContext, body any) string {
+	t.Helper()
+	out, err := NewPlaceholderController(nil, partials, nil).Render(ctx, CompileTemplate(body))
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	raw, _ := json.Marshal(out)
+	return string(raw)
+}
+
+func TestRenderPartials(t *testing.T) {
+	partials := partialsStub{
+		"envelope": map[string]any{"ok": true, "data": "{{with.data}}", "by": "{{> sig}}"},
+		"sig":      "mocky-{{path.id}}",
+		"static":   map[string]any{"kind": "static"},
+	}
+	ctx := MockContext{PathParams: map[string]string{"id": "7"}, Body: map[string]any{"n": 3.0, "c": "{{path.id}}x"}}
+
+	tests := []struct {
+		name string
+		body any
+		want string
+	}{
+		{
+			name: "with params are resolved in the caller context",
+			body: map[string]any{PartialKey: "envelope", PartialParamsKey: map[string]any{"data": map[string]any{"id": "{{path.id}}"}}},
+			want: `{"by":"mocky-7","data":{"id":"7"},"ok":true}`,
+		},
+		{
+			name: "extra keys are merged over the partial",
+			body: map[string]any{PartialKey: "static", "n": "{{body.n}}"},
+			want: `{"kind":"static","n":3}`,
+		},
+		{
+			name: "inline partial keeps its shape",
+			body: map[string]any{"sig": "{{> sig}}", "text": "by {{> sig}}"},
+			want: `{"sig":"mocky-7","text":"by mocky-7"}`,
+		},
+		{
+			name: "body values with placeholders are resolved in cascade",
+			body: map[string]any{"c": "{{body.c}}", "text": "c={{body.c}}"},
+			want: `{"c":"7x","text":"c=7x"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderJSON(t, partials, ctx, tt.body); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Mezclar llaves extra no modifica el partial registrado
+	if kind := partials["static"].(map[string]any); len(kind) != 1 {
+		t.Fatalf("static partial was mutated: %v", kind)
+	}
+}
+
+func TestRenderPartialErrors(t *testing.T) {
+	partials := partialsStub{"loop": map[string]any{PartialKey: "loop"}}
+
+	for name, body := range map[string]any{
+		"missing partial": map[string]any{PartialKey: "nope"},
+		"cyclic partial":  map[string]any{PartialKey: "loop"},
+		"name not string": map[string]any{PartialKey: 1.0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewPlaceholderController(nil, partials, nil).Render(MockContext{}, CompileTemplate(body)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package placeholder
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"mocky/internal/core/settings"
+)
+
+// VariableSource da acceso a las variables de /v1/variables: las globales
+// sobrescritas por las del group del prototype.
+type VariableSource interface {
+	Variables(ctx context.Context, group string) map[string]any
+}
+
+// ==== {{vars.base_url}}, {{vars.tenant.id}} ====
+
+func lookupVariable(expr string, ctx MockContext) any {
+	segs, err := parsePath(expr)
+	if err != nil {
+		return nil
+	}
+	return evalPath(ctx.vars, segs)
+}
+
+// ==== {{env.API_HOST}} (solo variables en TEMPLATE_ENV_ALLOWLIST) ====
+
+// lookupEnv devuelve ok=false si la variable no está permitida, así el
+// placeholder queda intacto y no se expone el entorno del proceso.
+func lookupEnv(name string) (any, bool) {
+	if !slices.Contains(settings.Settings.TEMPLATE_ENV_ALLOWLIST, name) {
+		return nil, false
+	}
+	return os.Getenv(name), true
+}
+
+func isEnvKey(name string) bool {
+	return strings.HasPrefix(name, "env.") && len(name) > len("env.")
+}
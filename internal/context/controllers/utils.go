@@ -2,15 +2,20 @@ package validator_controller
 
 import "mocky/internal/api/v1/prototypes/domain/entities"
 
+// frame is either an object to validate (props + value) or, when item is set,
+// the elements of an array validated one by one against item.
 type frame struct {
 	path                 string
 	schemaName           string
 	additionalProperties bool
 	props                []entities.PropertyEntity
 	value                map[string]any
+
+	item   *entities.PropertyEntity
+	values []any
 }
 
-// Each validator returns if passed (ok) and, for "object", a child frame to push.
+// Each validator returns if passed (ok) and, for "object" and "array", a child frame to push.
 type ValidationFunc func(
 	prop entities.PropertyEntity,
 	val any,
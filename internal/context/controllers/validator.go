@@ -2,59 +2,124 @@ package validator_controller
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"mocky/internal/api/v1/prototypes/domain/entities"
 )
 
+// joinPath agrega un campo a la ruta; los nombres con ".", "[" o comillas van entre
+// corchetes (filter["a.b"]) para que toPointer no los parta
 func joinPath(base, next string) string {
+	if strings.ContainsAny(next, `.[]"`) {
+		return base + "[" + strconv.Quote(next) + "]"
+	}
 	if base == "" {
 		return next
 	}
 	return base + "." + next
 }
 
+func indexPath(base string, i int) string {
+	return fmt.Sprintf("%s[%d]", base, i)
+}
+
 // ======== Validation Engine ========
 
-func ValidateAgainstSchema(schema entities.BodySchemaEntity, payload map[string]any, registry map[string]ValidationFunc) []ValidationError {
+type engine struct {
+	registry map[string]ValidationFunc
+}
+
+func ValidateAgainstSchema(schema entities.BodySchemaEntity, payload any, registry map[string]ValidationFunc) []ValidationError {
 	if registry == nil {
 		// Default registry
-		registry = buildValidatorRegistry()
+		registry = buildValidatorRegistry(nil, nil)
 	}
+	e := engine{registry: registry}
 
 	var errs []ValidationError
-	if strings.ToLower(schema.TypeSchema) != "object" {
-		errs = append(errs, ValidationError{Err: "root type_schema must be 'object'"})
+	var root entities.PropertyEntity
+
+	switch strings.ToLower(schema.TypeSchema) {
+	case "object":
+		if payload == nil {
+			payload = map[string]any{}
+		}
+		root = schema.AsObjectProperty()
+	case "array":
+		// Bulk endpoints: the root is validated like an "array" property
+		root = schema.AsArrayProperty()
+	default:
+		errs = append(errs, ValidationError{Err: "root type_schema must be 'object' or 'array'", Code: CodeSchema})
 		return errs
 	}
 
-	propIndex := func(props []entities.PropertyEntity) map[string]entities.PropertyEntity {
-		m := make(map[string]entities.PropertyEntity, len(props))
-		for _, p := range props {
-			m[p.Name] = p
+	var stack []frame
+	e.validateValue(root, payload, "", schema.AditionalProperties, &stack, &errs)
+	e.run(stack, &errs)
+	return errs
+}
+
+func propIndex(props []entities.PropertyEntity) map[string]entities.PropertyEntity {
+	m := make(map[string]entities.PropertyEntity, len(props))
+	for _, p := range props {
+		m[p.Name] = p
+	}
+	return m
+}
+
+// validateValue checks a single value and pushes the child frame of objects and arrays
+func (e engine) validateValue(p entities.PropertyEntity, raw any, path string, additional bool, stack *[]frame, errs *[]ValidationError) {
+	if raw == nil && p.Nullable {
+		return
+	}
+
+	// A branch can declare its own composition, so resolve until none is left
+	for p.HasComposition() {
+		effective, ok := e.resolveComposition(p, raw, path, additional, errs)
+		if !ok {
+			return
 		}
-		return m
+		p = effective
 	}
 
-	stack := []frame{{
-		path:                 "",
-		schemaName:           schema.Name,
-		additionalProperties: schema.AditionalProperties,
-		props:                schema.Properties,
-		value:                payload,
-	}}
+	validator, exists := e.registry[strings.ToLower(p.Type)]
+	if !exists {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("unsupported type in schema: %s", p.Type), Code: CodeSchema})
+		return
+	}
 
+	ok, next := validator(p, raw, path, additional, errs)
+	if !ok {
+		return
+	}
+	validateEnumConst(p, raw, path, errs)
+	if next != nil {
+		*stack = append(*stack, *next)
+	}
+}
+
+// run drains the stack of pending objects and arrays
+func (e engine) run(stack []frame, errs *[]ValidationError) {
 	for len(stack) > 0 {
 		f := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
 
+		// Array items
+		if f.item != nil {
+			for i, raw := range f.values {
+				e.validateValue(*f.item, raw, indexPath(f.path, i), f.additionalProperties, &stack, errs)
+			}
+			continue
+		}
+
 		propsByName := propIndex(f.props)
 
 		// Required fields
 		for _, p := range f.props {
 			if p.IsRequired {
 				if _, ok := f.value[p.Name]; !ok {
-					errs = append(errs, ValidationError{Path: joinPath(f.path, p.Name), Err: "missing required field"})
+					*errs = append(*errs, ValidationError{Path: joinPath(f.path, p.Name), Err: "missing required field", Code: CodeRequired})
 				}
 			}
 		}
@@ -63,7 +128,7 @@ func ValidateAgainstSchema(schema entities.BodySchemaEntity, payload map[string]
 		if !f.additionalProperties {
 			for k := range f.value {
 				if _, ok := propsByName[k]; !ok {
-					errs = append(errs, ValidationError{Path: joinPath(f.path, k), Err: "property not allowed (additional_properties=false)"})
+					*errs = append(*errs, ValidationError{Path: joinPath(f.path, k), Err: "property not allowed (additional_properties=false)", Code: CodeAdditionalProperties})
 				}
 			}
 		}
@@ -72,20 +137,23 @@ func ValidateAgainstSchema(schema entities.BodySchemaEntity, payload map[string]
 		for _, p := range f.props {
 			raw, ok := f.value[p.Name]
 			if !ok {
+				// Defaults are written into the payload so templates can read them; as a
+				// copy, so a request that mutates its body never touches the prototype
+				if p.Default != nil && f.value != nil {
+					f.value[p.Name] = deepCopy(p.Default)
+				}
 				continue
 			}
-			propPath := joinPath(f.path, p.Name)
-
-			validator, exists := registry[strings.ToLower(p.Type)]
-			if !exists {
-				errs = append(errs, ValidationError{Path: propPath, Err: fmt.Sprintf("unsupported type in schema: %s", p.Type)})
-				continue
-			}
-
-			if ok, next := validator(p, raw, propPath, f.additionalProperties, &errs); ok && next != nil {
-				stack = append(stack, *next)
-			}
+			e.validateValue(p, raw, joinPath(f.path, p.Name), f.additionalProperties, &stack, errs)
 		}
 	}
-	return errs
+}
+
+// matches validates a copy of the value, so defaults of discarded branches don't leak into the body
+func (e engine) matches(p entities.PropertyEntity, raw any, additional bool) bool {
+	var errs []ValidationError
+	var stack []frame
+	e.validateValue(p, deepCopy(raw), "", additional, &stack, &errs)
+	e.run(stack, &errs)
+	return len(errs) == 0
 }
@@ -1,57 +1,77 @@
 package validator_controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"mocky/internal/api/v1/prototypes/domain/entities"
+	"mocky/internal/context/controllers/jsonschema"
 )
 
 // ======== Validator Registry ========
 
-func buildValidatorRegistry() map[string]ValidationFunc {
+// patterns holds the regexes compiled for a prototype by Compile; a pattern missing
+// from it is reported as a schema error instead of being compiled per request.
+func buildValidatorRegistry(formats CustomFormatSource, patterns map[string]*regexp.Regexp) map[string]ValidationFunc {
 	return map[string]ValidationFunc{
-		"string":  validateString,
+		"string":  stringValidator(formats, patterns),
 		"boolean": validateBoolean,
 		"number":  validateNumber,
 		"integer": validateInteger,
 		"object":  validateObject,
+		"array":   validateArray,
 	}
 }
 
 // ======== Validators ========
 
-func validateString(p entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
+// stringValidator binds the custom formats registered in /v1/formats and the compiled patterns
+func stringValidator(formats CustomFormatSource, patterns map[string]*regexp.Regexp) ValidationFunc {
+	return func(p entities.PropertyEntity, val any, path string, parentAdditional bool, errs *[]ValidationError) (bool, *frame) {
+		return validateString(p, val, path, formats, patterns, errs)
+	}
+}
+
+func validateString(p entities.PropertyEntity, val any, path string, formats CustomFormatSource, patterns map[string]*regexp.Regexp, errs *[]ValidationError) (bool, *frame) {
 	s, ok := val.(string)
 	if !ok {
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected string, got %T", val)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected string, got %T", val), Code: CodeType})
 		return false, nil
 	}
 	if p.MinLength > 0 && int32(len(s)) < p.MinLength {
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("minimum length is %d", p.MinLength)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("minimum length is %d", p.MinLength), Code: CodeMinLength})
 	}
 	if p.MaxLength > 0 && int32(len(s)) > p.MaxLength {
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("maximum length is %d", p.MaxLength)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("maximum length is %d", p.MaxLength), Code: CodeMaxLength})
 	}
 	if p.Pattern != "" {
-		re, compErr := regexp.Compile(p.Pattern)
-		if compErr != nil {
-			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("invalid regex in schema: %v", compErr)})
+		// Compile registers every pattern of the schema, so a miss is a bug, not a bad request
+		re := patterns[p.Pattern]
+		if re == nil {
+			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("pattern %q was not compiled with the schema", p.Pattern), Code: CodeSchema})
 		} else if !re.MatchString(s) {
-			*errs = append(*errs, ValidationError{Path: path, Err: "value does not match required pattern"})
+			*errs = append(*errs, ValidationError{Path: path, Err: "value does not match required pattern", Code: CodePattern})
 		}
 	}
 	if p.Format != "" {
 		switch strings.ToLower(p.Format) {
 		case "email":
-			if !isEmail(s) {
-				*errs = append(*errs, ValidationError{Path: path, Err: "invalid email format"})
+			if !jsonschema.IsEmail(s) {
+				*errs = append(*errs, ValidationError{Path: path, Err: "invalid email format", Code: CodeFormat})
 			}
 		case "date":
 			if !isYYYYMMDD(s) {
-				*errs = append(*errs, ValidationError{Path: path, Err: "invalid date format (expected YYYY-MM-DD)"})
+				*errs = append(*errs, ValidationError{Path: path, Err: "invalid date format (expected YYYY-MM-DD)", Code: CodeFormat})
+			}
+		default:
+			valid, registered := checkFormat(p.Format, s, formats)
+			if !registered {
+				*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("format %s is not registered", p.Format), Code: CodeFormat})
+			} else if !valid {
+				*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("invalid %s format", p.Format), Code: CodeFormat})
 			}
 		}
 	}
@@ -60,59 +80,159 @@ func validateString(p entities.PropertyEntity, val any, path string, _ bool, err
 
 func validateBoolean(_ entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
 	if _, ok := val.(bool); !ok {
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected boolean, got %T", val)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected boolean, got %T", val), Code: CodeType})
 		return false, nil
 	}
 	return true, nil
 }
 
-func validateNumber(_ entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
-	switch val.(type) {
-	case float64, float32, int, int32, int64:
-		return true, nil
-	default:
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected number, got %T", val)})
+func validateNumber(p entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
+	n, ok := toFloat(val)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected number, got %T", val), Code: CodeType})
 		return false, nil
 	}
+	validateRange(p, n, path, errs)
+	return true, nil
 }
 
-func validateInteger(_ entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
+func validateInteger(p entities.PropertyEntity, val any, path string, _ bool, errs *[]ValidationError) (bool, *frame) {
 	switch v := val.(type) {
 	case float64:
 		if v != float64(int64(v)) {
-			*errs = append(*errs, ValidationError{Path: path, Err: "expected integer, got float"})
+			*errs = append(*errs, ValidationError{Path: path, Err: "expected integer, got float", Code: CodeType})
 			return false, nil
 		}
+		validateRange(p, v, path, errs)
 		return true, nil
 	case int, int32, int64:
+		n, _ := toFloat(v)
+		validateRange(p, n, path, errs)
 		return true, nil
 	default:
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected integer, got %T", val)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected integer, got %T", val), Code: CodeType})
 		return false, nil
 	}
 }
 
+// validateRange checks minimum/maximum, exclusive bounds and multiple_of
+func validateRange(p entities.PropertyEntity, n float64, path string, errs *[]ValidationError) {
+	if p.Minimum != nil && n < *p.Minimum {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("must be >= %v", *p.Minimum), Code: CodeMinimum})
+	}
+	if p.Maximum != nil && n > *p.Maximum {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("must be <= %v", *p.Maximum), Code: CodeMaximum})
+	}
+	if p.ExclusiveMinimum != nil && n <= *p.ExclusiveMinimum {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("must be > %v", *p.ExclusiveMinimum), Code: CodeExclusiveMinimum})
+	}
+	if p.ExclusiveMaximum != nil && n >= *p.ExclusiveMaximum {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("must be < %v", *p.ExclusiveMaximum), Code: CodeExclusiveMaximum})
+	}
+	if p.MultipleOf != nil && !jsonschema.IsMultipleOf(n, *p.MultipleOf) {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("must be a multiple of %v", *p.MultipleOf), Code: CodeMultipleOf})
+	}
+}
+
+// validateEnumConst applies to every type, once the value passed its type validator
+func validateEnumConst(p entities.PropertyEntity, val any, path string, errs *[]ValidationError) {
+	if len(p.Enum) > 0 {
+		found := false
+		for _, option := range p.Enum {
+			if canonicalJSON(option) == canonicalJSON(val) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("value must be one of %s", canonicalJSON(p.Enum)), Code: CodeEnum})
+		}
+	}
+	if p.Const != nil && canonicalJSON(p.Const) != canonicalJSON(val) {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("value must be %s", canonicalJSON(p.Const)), Code: CodeConst})
+	}
+}
+
 func validateObject(p entities.PropertyEntity, val any, path string, parentAdditional bool, errs *[]ValidationError) (bool, *frame) {
 	m, ok := val.(map[string]any)
 	if !ok {
-		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected object, got %T", val)})
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected object, got %T", val), Code: CodeType})
 		return false, nil
 	}
+	// Each object may override the additional_properties of its parent
+	additional := parentAdditional
+	if p.AdditionalProperties != nil {
+		additional = *p.AdditionalProperties
+	}
 	childFrame := &frame{
 		path:                 path,
 		schemaName:           p.Name,
-		additionalProperties: parentAdditional,
+		additionalProperties: additional,
 		props:                p.Properties,
 		value:                m,
 	}
 	return true, childFrame
 }
 
+func validateArray(p entities.PropertyEntity, val any, path string, parentAdditional bool, errs *[]ValidationError) (bool, *frame) {
+	arr, ok := val.([]any)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("expected array, got %T", val), Code: CodeType})
+		return false, nil
+	}
+	if p.MinItems > 0 && int32(len(arr)) < p.MinItems {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("minimum items is %d", p.MinItems), Code: CodeMinItems})
+	}
+	if p.MaxItems > 0 && int32(len(arr)) > p.MaxItems {
+		*errs = append(*errs, ValidationError{Path: path, Err: fmt.Sprintf("maximum items is %d", p.MaxItems), Code: CodeMaxItems})
+	}
+	if p.UniqueItems {
+		seen := make(map[string]int, len(arr))
+		for i, item := range arr {
+			key := canonicalJSON(item)
+			if first, dup := seen[key]; dup {
+				*errs = append(*errs, ValidationError{Path: indexPath(path, i), Err: fmt.Sprintf("duplicated item (same as index %d)", first), Code: CodeUniqueItems})
+				continue
+			}
+			seen[key] = i
+		}
+	}
+	if p.Items == nil {
+		return true, nil
+	}
+	childFrame := &frame{
+		path:                 path,
+		schemaName:           p.Name,
+		additionalProperties: parentAdditional,
+		item:                 p.Items,
+		values:               arr,
+	}
+	return true, childFrame
+}
+
 // ======== Utils ========
 
-var emailRe = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
 
-func isEmail(s string) bool { return emailRe.MatchString(s) }
+// canonicalJSON serializes a value with sorted keys so equal items compare equal
+func canonicalJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
 
 func isYYYYMMDD(s string) bool {
 	if len(s) != 10 {
@@ -3,7 +3,11 @@ package server
 import (
 	"fmt"
 	"mocky/internal/api/health"
+	"mocky/internal/api/v1/datasets"
+	"mocky/internal/api/v1/formats"
+	"mocky/internal/api/v1/partials"
 	"mocky/internal/api/v1/prototypes"
+	"mocky/internal/api/v1/variables"
 	"mocky/internal/core/router"
 	"mocky/internal/core/settings"
 
@@ -37,8 +41,20 @@ func setUpRouter() *gin.Engine {
 	// Rutas de health
 	health.SetupHealthModule(r)
 
+	// Rutas de datasets
+	datasetsService := datasets.SetupDatasetsModule(r)
+
+	// Rutas de partials
+	partialsService := partials.SetupPartialsModule(r)
+
+	// Rutas de variables
+	variablesService := variables.SetupVariablesModule(r)
+
+	// Rutas de formats
+	formatsService := formats.SetupFormatsModule(r)
+
 	// Rutas de prototypes
-	prototypes.SetupPrototypesModule(r)
+	prototypes.SetupPrototypesModule(r, datasetsService, partialsService, variablesService, formatsService)
 
 	return r
 }
@@ -28,6 +28,11 @@ type Config struct {
 	ROOT_PATH string `required:"false" default:""`
 
 	LOKI_URL string `required:"false" default:"http://localhost:3100"`
+
+	// Templates
+	HMAC_SECRET string `required:"false" default:"" sensitive:"true"`
+	// Variables de entorno legibles como {{env.X}} (separadas por coma)
+	TEMPLATE_ENV_ALLOWLIST []string `required:"false"`
 }
 
 var Settings Config
@@ -88,6 +93,13 @@ func LoadEnvs() {
 		for i := 0; i < v.NumField(); i++ {
 			fieldName := t.Field(i).Name
 			fieldValue := v.Field(i).Interface()
+			// los secretos no se imprimen, solo si están configurados
+			if t.Field(i).Tag.Get("sensitive") == "true" {
+				fieldValue = "<unset>"
+				if !v.Field(i).IsZero() {
+					fieldValue = "<redacted>"
+				}
+			}
 			log.Printf("  %s: %v", fieldName, fieldValue)
 		}
 	}
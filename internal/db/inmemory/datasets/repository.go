@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"mocky/internal/db/mongo/datasets"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// entrada con TTL
+type entry struct {
+	model     datasets.DatasetModel
+	expiresAt time.Time
+}
+
+type InMemoryDatasetsRepository struct {
+	mu     sync.RWMutex
+	store  map[string]entry
+	byName map[string]string
+	ttl    time.Duration
+}
+
+// NewInMemoryDatasetsRepository crea un repo con TTL fijo por entrada.
+// ttl: tiempo de vida de cada registro (si <=0 usa 5 min).
+func NewInMemoryDatasetsRepository(ttl time.Duration) *InMemoryDatasetsRepository {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &InMemoryDatasetsRepository{
+		store:  make(map[string]entry),
+		byName: make(map[string]string),
+		ttl:    ttl,
+	}
+}
+
+// ===================== Helpers =====================
+
+func (r *InMemoryDatasetsRepository) put(id string, m datasets.DatasetModel) {
+	r.store[id] = entry{
+		model:     m,
+		expiresAt: time.Now().Add(r.ttl),
+	}
+	r.byName[m.Name] = id
+}
+
+func (r *InMemoryDatasetsRepository) getIfAliveByID(id string) (datasets.DatasetModel, bool) {
+	e, ok := r.store[id]
+	if !ok {
+		return datasets.DatasetModel{}, false
+	}
+	if time.Now().After(e.expiresAt) {
+		// caducado: limpiar
+		delete(r.byName, e.model.Name)
+		delete(r.store, id)
+		return datasets.DatasetModel{}, false
+	}
+	return e.model, true
+}
+
+func (r *InMemoryDatasetsRepository) getIfAliveByName(name string) (datasets.DatasetModel, bool) {
+	id, ok := r.byName[name]
+	if !ok {
+		return datasets.DatasetModel{}, false
+	}
+	return r.getIfAliveByID(id)
+}
+
+// ================= Implementación RepositoryDatasets =================
+
+func (r *InMemoryDatasetsRepository) Find(ctx context.Context, id string) utils.Result[datasets.DatasetModel] {
+	r.mu.Lock() // Lock para poder purgar si expiró
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByID(id)
+	if !ok {
+		return utils.Result[datasets.DatasetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "no se encontró el dataset", "inmemory.find")}
+	}
+	return utils.Result[datasets.DatasetModel]{Data: m}
+}
+
+func (r *InMemoryDatasetsRepository) FindAll(ctx context.Context) utils.Result[[]datasets.DatasetListModel] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]datasets.DatasetListModel, 0, len(r.store))
+	for id := range r.store {
+		m, ok := r.getIfAliveByID(id)
+		if !ok {
+			continue
+		}
+		list = append(list, datasets.DatasetListModel{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			Name:      m.Name,
+			Size:      m.Size,
+		})
+	}
+	return utils.Result[[]datasets.DatasetListModel]{Data: list}
+}
+
+func (r *InMemoryDatasetsRepository) GetByName(cc *customctx.CustomContext, name string) utils.Result[datasets.DatasetModel] {
+	r.mu.Lock() // Lock para poder purgar si caducó
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByName(name)
+	if !ok {
+		return utils.Result[datasets.DatasetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have dataset with name: "+name, "inmemory.get_by_name")}
+	}
+	return utils.Result[datasets.DatasetModel]{Data: m}
+}
+
+func (r *InMemoryDatasetsRepository) SaveOrUpdate(cc *customctx.CustomContext, document datasets.DatasetModel) utils.Result[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	document.Size = len(document.Records)
+	document.UpdatedAt = time.Now()
+
+	existing, ok := r.getIfAliveByName(document.Name)
+	if !ok {
+		// nuevo
+		document.ID = primitive.NewObjectID().Hex()
+		document.CreatedAt = time.Now()
+		r.put(document.ID, document)
+		return utils.Result[string]{Data: document.ID}
+	}
+
+	// reemplazo preservando ID y CreatedAt
+	document.ID = existing.ID
+	document.CreatedAt = existing.CreatedAt
+	r.put(document.ID, document)
+
+	return utils.Result[string]{Data: document.ID}
+}
+
+func (r *InMemoryDatasetsRepository) DeleteByName(cc *customctx.CustomContext, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byName[name]
+	if !ok {
+		return cerrs.NewCustomError(http.StatusNotFound, "no se encontró el dataset", "inmemory.delete_by_name")
+	}
+	delete(r.byName, name)
+	delete(r.store, id)
+	return nil
+}
@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"mocky/internal/db/mongo/formats"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// entrada con TTL
+type entry struct {
+	model     formats.FormatModel
+	expiresAt time.Time
+}
+
+type InMemoryFormatsRepository struct {
+	mu     sync.RWMutex
+	store  map[string]entry
+	byName map[string]string
+	ttl    time.Duration
+}
+
+// NewInMemoryFormatsRepository crea un repo con TTL fijo por entrada.
+// ttl: tiempo de vida de cada registro (si <=0 usa 5 min).
+func NewInMemoryFormatsRepository(ttl time.Duration) *InMemoryFormatsRepository {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &InMemoryFormatsRepository{
+		store:  make(map[string]entry),
+		byName: make(map[string]string),
+		ttl:    ttl,
+	}
+}
+
+// ===================== Helpers =====================
+
+func (r *InMemoryFormatsRepository) put(id string, m formats.FormatModel) {
+	r.store[id] = entry{
+		model:     m,
+		expiresAt: time.Now().Add(r.ttl),
+	}
+	r.byName[m.Name] = id
+}
+
+func (r *InMemoryFormatsRepository) getIfAliveByID(id string) (formats.FormatModel, bool) {
+	e, ok := r.store[id]
+	if !ok {
+		return formats.FormatModel{}, false
+	}
+	if time.Now().After(e.expiresAt) {
+		// caducado: limpiar
+		delete(r.byName, e.model.Name)
+		delete(r.store, id)
+		return formats.FormatModel{}, false
+	}
+	return e.model, true
+}
+
+func (r *InMemoryFormatsRepository) getIfAliveByName(name string) (formats.FormatModel, bool) {
+	id, ok := r.byName[name]
+	if !ok {
+		return formats.FormatModel{}, false
+	}
+	return r.getIfAliveByID(id)
+}
+
+// ================= Implementación RepositoryFormats =================
+
+func (r *InMemoryFormatsRepository) Find(ctx context.Context, id string) utils.Result[formats.FormatModel] {
+	r.mu.Lock() // Lock para poder purgar si expiró
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByID(id)
+	if !ok {
+		return utils.Result[formats.FormatModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "no se encontró el format", "inmemory.find")}
+	}
+	return utils.Result[formats.FormatModel]{Data: m}
+}
+
+func (r *InMemoryFormatsRepository) FindAll(ctx context.Context) utils.Result[[]formats.FormatListModel] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]formats.FormatListModel, 0, len(r.store))
+	for id := range r.store {
+		m, ok := r.getIfAliveByID(id)
+		if !ok {
+			continue
+		}
+		list = append(list, formats.FormatListModel{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			Name:      m.Name,
+			Pattern:   m.Pattern,
+			Checksum:  m.Checksum,
+		})
+	}
+	return utils.Result[[]formats.FormatListModel]{Data: list}
+}
+
+func (r *InMemoryFormatsRepository) GetByName(cc *customctx.CustomContext, name string) utils.Result[formats.FormatModel] {
+	r.mu.Lock() // Lock para poder purgar si caducó
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByName(name)
+	if !ok {
+		return utils.Result[formats.FormatModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have format with name: "+name, "inmemory.get_by_name")}
+	}
+	return utils.Result[formats.FormatModel]{Data: m}
+}
+
+func (r *InMemoryFormatsRepository) SaveOrUpdate(cc *customctx.CustomContext, document formats.FormatModel) utils.Result[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	document.UpdatedAt = time.Now()
+
+	existing, ok := r.getIfAliveByName(document.Name)
+	if !ok {
+		// nuevo
+		document.ID = primitive.NewObjectID().Hex()
+		document.CreatedAt = time.Now()
+		r.put(document.ID, document)
+		return utils.Result[string]{Data: document.ID}
+	}
+
+	// reemplazo preservando ID y CreatedAt
+	document.ID = existing.ID
+	document.CreatedAt = existing.CreatedAt
+	r.put(document.ID, document)
+
+	return utils.Result[string]{Data: document.ID}
+}
+
+func (r *InMemoryFormatsRepository) DeleteByName(cc *customctx.CustomContext, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byName[name]
+	if !ok {
+		return cerrs.NewCustomError(http.StatusNotFound, "no se encontró el format", "inmemory.delete_by_name")
+	}
+	delete(r.byName, name)
+	delete(r.store, id)
+	return nil
+}
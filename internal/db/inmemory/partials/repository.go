@@ -0,0 +1,152 @@
+package inmemory
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"mocky/internal/db/mongo/partials"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// entrada con TTL
+type entry struct {
+	model     partials.PartialModel
+	expiresAt time.Time
+}
+
+type InMemoryPartialsRepository struct {
+	mu     sync.RWMutex
+	store  map[string]entry
+	byName map[string]string
+	ttl    time.Duration
+}
+
+// NewInMemoryPartialsRepository crea un repo con TTL fijo por entrada.
+// ttl: tiempo de vida de cada registro (si <=0 usa 5 min).
+func NewInMemoryPartialsRepository(ttl time.Duration) *InMemoryPartialsRepository {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &InMemoryPartialsRepository{
+		store:  make(map[string]entry),
+		byName: make(map[string]string),
+		ttl:    ttl,
+	}
+}
+
+// ===================== Helpers =====================
+
+func (r *InMemoryPartialsRepository) put(id string, m partials.PartialModel) {
+	r.store[id] = entry{
+		model:     m,
+		expiresAt: time.Now().Add(r.ttl),
+	}
+	r.byName[m.Name] = id
+}
+
+func (r *InMemoryPartialsRepository) getIfAliveByID(id string) (partials.PartialModel, bool) {
+	e, ok := r.store[id]
+	if !ok {
+		return partials.PartialModel{}, false
+	}
+	if time.Now().After(e.expiresAt) {
+		// caducado: limpiar
+		delete(r.byName, e.model.Name)
+		delete(r.store, id)
+		return partials.PartialModel{}, false
+	}
+	return e.model, true
+}
+
+func (r *InMemoryPartialsRepository) getIfAliveByName(name string) (partials.PartialModel, bool) {
+	id, ok := r.byName[name]
+	if !ok {
+		return partials.PartialModel{}, false
+	}
+	return r.getIfAliveByID(id)
+}
+
+// ================= Implementación RepositoryPartials =================
+
+func (r *InMemoryPartialsRepository) Find(ctx context.Context, id string) utils.Result[partials.PartialModel] {
+	r.mu.Lock() // Lock para poder purgar si expiró
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByID(id)
+	if !ok {
+		return utils.Result[partials.PartialModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "no se encontró el partial", "inmemory.find")}
+	}
+	return utils.Result[partials.PartialModel]{Data: m}
+}
+
+func (r *InMemoryPartialsRepository) FindAll(ctx context.Context) utils.Result[[]partials.PartialListModel] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]partials.PartialListModel, 0, len(r.store))
+	for id := range r.store {
+		m, ok := r.getIfAliveByID(id)
+		if !ok {
+			continue
+		}
+		list = append(list, partials.PartialListModel{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			Name:      m.Name,
+		})
+	}
+	return utils.Result[[]partials.PartialListModel]{Data: list}
+}
+
+func (r *InMemoryPartialsRepository) GetByName(cc *customctx.CustomContext, name string) utils.Result[partials.PartialModel] {
+	r.mu.Lock() // Lock para poder purgar si caducó
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByName(name)
+	if !ok {
+		return utils.Result[partials.PartialModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have partial with name: "+name, "inmemory.get_by_name")}
+	}
+	return utils.Result[partials.PartialModel]{Data: m}
+}
+
+func (r *InMemoryPartialsRepository) SaveOrUpdate(cc *customctx.CustomContext, document partials.PartialModel) utils.Result[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	document.UpdatedAt = time.Now()
+
+	existing, ok := r.getIfAliveByName(document.Name)
+	if !ok {
+		// nuevo
+		document.ID = primitive.NewObjectID().Hex()
+		document.CreatedAt = time.Now()
+		r.put(document.ID, document)
+		return utils.Result[string]{Data: document.ID}
+	}
+
+	// reemplazo preservando ID y CreatedAt
+	document.ID = existing.ID
+	document.CreatedAt = existing.CreatedAt
+	r.put(document.ID, document)
+
+	return utils.Result[string]{Data: document.ID}
+}
+
+func (r *InMemoryPartialsRepository) DeleteByName(cc *customctx.CustomContext, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byName[name]
+	if !ok {
+		return cerrs.NewCustomError(http.StatusNotFound, "no se encontró el partial", "inmemory.delete_by_name")
+	}
+	delete(r.byName, name)
+	delete(r.store, id)
+	return nil
+}
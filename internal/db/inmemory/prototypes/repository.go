@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"mocky/internal/db/mongo/prototypes"
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -153,16 +155,33 @@ func (r *InMemoryPrototypesRepository) Update(ctx context.Context, entity protot
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, ok := r.getIfAliveByID(entity.ID)
+	cur, ok := r.getIfAliveByID(entity.ID)
 	if !ok {
 		return cerrs.NewCustomError(http.StatusNotFound, "no existe el prototipo", "inmemory.update")
 	}
+	if err := r.reindex(cur, entity, "inmemory.update"); err != nil {
+		return err
+	}
 
 	entity.UpdatedAt = time.Now()
 	r.put(entity.ID, entity)
 	return nil
 }
 
+// reindex libera la llave method+urlPath anterior cuando cambia; la nueva no puede ser de otro prototipo
+func (r *InMemoryPrototypesRepository) reindex(cur, next prototypes.PrototypeModel, scope string) cerrs.CustomErrorInterface {
+	oldKey := keyFor(cur.Request.Method, cur.Request.UrlPath)
+	newKey := keyFor(next.Request.Method, next.Request.UrlPath)
+	if oldKey == newKey {
+		return nil
+	}
+	if other, taken := r.getIfAliveByKey(next.Request.Method, next.Request.UrlPath); taken && other.ID != cur.ID {
+		return cerrs.NewCustomError(http.StatusConflict, "ya existe un prototipo para "+next.Request.Method+" "+next.Request.UrlPath+" ("+other.ID+")", scope)
+	}
+	delete(r.byPathKey, oldKey)
+	return nil
+}
+
 func (r *InMemoryPrototypesRepository) UpdateFields(ctx context.Context, id string, updates map[string]interface{}) utils.Result[prototypes.PrototypeModel] {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -197,6 +216,9 @@ func (r *InMemoryPrototypesRepository) UpdateFields(ctx context.Context, id stri
 	if out.CreatedAt.IsZero() {
 		out.CreatedAt = cur.CreatedAt
 	}
+	if err := r.reindex(cur, out, "inmemory.update_fields"); err != nil {
+		return utils.Result[prototypes.PrototypeModel]{Err: err}
+	}
 	r.put(id, out)
 
 	return utils.Result[prototypes.PrototypeModel]{Data: out}
@@ -216,6 +238,23 @@ func (r *InMemoryPrototypesRepository) Delete(ctx context.Context, id string) er
 	return nil
 }
 
+func (r *InMemoryPrototypesRepository) DeleteAll(cc *customctx.CustomContext) utils.Result[int64] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// los caducados ya no existen para el resto del repo: no cuentan como eliminados
+	now := time.Now()
+	deleted := int64(0)
+	for _, e := range r.store {
+		if !now.After(e.expiresAt) {
+			deleted++
+		}
+	}
+	r.store = make(map[string]entry)
+	r.byPathKey = make(map[string]string)
+	return utils.Result[int64]{Data: deleted}
+}
+
 func (r *InMemoryPrototypesRepository) Find(ctx context.Context, id string) utils.Result[prototypes.PrototypeModel] {
 	r.mu.Lock() // Lock para poder purgar si expiró
 	defer r.mu.Unlock()
@@ -248,27 +287,66 @@ func (r *InMemoryPrototypesRepository) FindAll(ctx context.Context) utils.Result
 }
 
 func (r *InMemoryPrototypesRepository) Matching(cr criteria.Criteria, _ string, offset int, limit int) utils.Result[[]prototypes.PrototypeListModel] {
-	var wantURL, wantMethod *string
-	if cr.Filters.Get != nil {
-		for _, f := range cr.Filters.Get() {
-			switch strings.ToLower(string(f.Field)) {
-			case "request.urlpath":
-				if s, ok := f.Value.(string); ok {
-					wantURL = &s
-				}
-			case "request.method":
-				if s, ok := f.Value.(string); ok {
-					wantMethod = &s
-				}
-			}
+	matched, err := r.matching(cr)
+	if err != nil {
+		return utils.Result[[]prototypes.PrototypeListModel]{Err: err}
+	}
+
+	sortModels(matched, cr.Orders)
+
+	// paginación
+	start := offset
+	if start < 0 {
+		start = 0
+	}
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	items := make([]prototypes.PrototypeListModel, 0, end-start)
+	if r.toList != nil {
+		for _, m := range matched[start:end] {
+			items = append(items, r.toList(m))
 		}
 	}
+	return utils.Result[[]prototypes.PrototypeListModel]{Data: items}
+}
+
+func (r *InMemoryPrototypesRepository) CountMatching(cr criteria.Criteria, _ string) utils.Result[int64] {
+	matched, err := r.matching(cr)
+	if err != nil {
+		return utils.Result[int64]{Err: err}
+	}
+	return utils.Result[int64]{Data: int64(len(matched))}
+}
+
+// matching devuelve los prototipos vivos que cumplen todos los filtros
+func (r *InMemoryPrototypesRepository) matching(cr criteria.Criteria) ([]prototypes.PrototypeModel, cerrs.CustomErrorInterface) {
+	filters := cr.Filters.Get()
+
+	// los LIKE se comportan como en Mongo: regex sin distinguir mayúsculas
+	patterns := map[string]*regexp.Regexp{}
+	for _, f := range filters {
+		if f.Operator != criteria.OperatorLike && f.Operator != criteria.OperatorNotLike {
+			continue
+		}
+		pattern, _ := f.Value.(string)
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, cerrs.NewCustomError(http.StatusBadRequest, "regex inválida en el filtro "+string(f.Field)+": "+err.Error(), "inmemory.matching")
+		}
+		patterns[pattern] = re
+	}
 
 	now := time.Now()
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	items := make([]prototypes.PrototypeListModel, 0, len(r.store))
+	matched := make([]prototypes.PrototypeModel, 0, len(r.store))
 	for id, e := range r.store {
 		if now.After(e.expiresAt) {
 			key := keyFor(e.model.Request.Method, e.model.Request.UrlPath)
@@ -276,30 +354,134 @@ func (r *InMemoryPrototypesRepository) Matching(cr criteria.Criteria, _ string,
 			delete(r.store, id)
 			continue
 		}
-		if wantURL != nil && e.model.Request.UrlPath != *wantURL {
-			continue
+		ok := true
+		for _, f := range filters {
+			if !matchesFilter(e.model, f, patterns) {
+				ok = false
+				break
+			}
 		}
-		if wantMethod != nil && !strings.EqualFold(e.model.Request.Method, *wantMethod) {
-			continue
+		if ok {
+			matched = append(matched, e.model)
 		}
-		if r.toList != nil {
-			items = append(items, r.toList(e.model))
+	}
+	return matched, nil
+}
+
+// fieldValue resuelve los campos de criteria (nombres de Mongo) sobre el modelo
+func fieldValue(m prototypes.PrototypeModel, field criteria.FilterField) (any, bool) {
+	switch string(field) {
+	case "_id", "id":
+		return m.ID, true
+	case "name":
+		return m.Name, true
+	case "group":
+		return m.Group, true
+	case "request.method":
+		return strings.ToUpper(m.Request.Method), true
+	case "request.urlPath":
+		return m.Request.UrlPath, true
+	case "createdAt":
+		return m.CreatedAt, true
+	case "updatedAt":
+		return m.UpdatedAt, true
+	}
+	return nil, false
+}
+
+func matchesFilter(m prototypes.PrototypeModel, f criteria.Filter, patterns map[string]*regexp.Regexp) bool {
+	value, ok := fieldValue(m, f.Field)
+	if !ok {
+		return false
+	}
+
+	switch f.Operator {
+	case criteria.OperatorLike, criteria.OperatorNotLike:
+		pattern, _ := f.Value.(string)
+		text, _ := value.(string)
+		return patterns[pattern].MatchString(text) == (f.Operator == criteria.OperatorLike)
+	case criteria.OperatorIn, criteria.OperatorNotIn:
+		found := false
+		for _, candidate := range toSlice(f.Value) {
+			if cmp, comparable := compareValues(value, candidate); comparable && cmp == 0 {
+				found = true
+				break
+			}
 		}
+		return found == (f.Operator == criteria.OperatorIn)
 	}
 
-	// paginación
-	start := offset
-	if start < 0 {
-		start = 0
+	cmp, comparable := compareValues(value, f.Value)
+	if !comparable {
+		return f.Operator == criteria.OperatorNotEqual
 	}
-	if start > len(items) {
-		start = len(items)
+	switch f.Operator {
+	case criteria.OperatorEqual:
+		return cmp == 0
+	case criteria.OperatorNotEqual:
+		return cmp != 0
+	case criteria.OperatorGreaterThan:
+		return cmp > 0
+	case criteria.OperatorGreaterEqual:
+		return cmp >= 0
+	case criteria.OperatorLessThan:
+		return cmp < 0
+	case criteria.OperatorLessEqual:
+		return cmp <= 0
 	}
-	end := len(items)
-	if limit > 0 && start+limit < end {
-		end = start + limit
+	return false
+}
+
+func toSlice(v any) []any {
+	switch typed := v.(type) {
+	case []any:
+		return typed
+	case []string:
+		out := make([]any, len(typed))
+		for i, s := range typed {
+			out[i] = s
+		}
+		return out
+	}
+	return []any{v}
+}
+
+// compareValues compara strings o fechas; comparable=false si los tipos no coinciden
+func compareValues(a, b any) (int, bool) {
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	case time.Time:
+		y, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return x.Compare(y), true
 	}
-	return utils.Result[[]prototypes.PrototypeListModel]{Data: items[start:end]}
+	return 0, false
+}
+
+// sortModels ordena según los criterios; el ID desempata para que la paginación sea estable
+func sortModels(models []prototypes.PrototypeModel, orders []criteria.Order) {
+	sort.SliceStable(models, func(i, j int) bool {
+		for _, o := range orders {
+			a, _ := fieldValue(models[i], o.Field)
+			b, _ := fieldValue(models[j], o.Field)
+			cmp, _ := compareValues(a, b)
+			if cmp == 0 {
+				continue
+			}
+			if o.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return models[i].ID < models[j].ID
+	})
 }
 
 func (r *InMemoryPrototypesRepository) GetByPath(cc *customctx.CustomContext, urlPath string, method string) utils.Result[prototypes.PrototypeModel] {
@@ -313,19 +495,42 @@ func (r *InMemoryPrototypesRepository) GetByPath(cc *customctx.CustomContext, ur
 	defer r.mu.Unlock()
 
 	m, ok := r.getIfAliveByKey(method, urlPath)
+	if !ok {
+		m, ok = r.getIfAliveByTemplate(method, urlPath)
+	}
 	if !ok {
 		return utils.Result[prototypes.PrototypeModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have prototype for this path: "+urlPath+" and method: "+method, "inmemory.get_by_path")}
 	}
 	return utils.Result[prototypes.PrototypeModel]{Data: m}
 }
 
+// getIfAliveByTemplate busca entre los prototipos con path dinámico (/v1/users/:id) el más
+// específico; los empates se resuelven con OutranksTemplate
+func (r *InMemoryPrototypesRepository) getIfAliveByTemplate(method, urlPath string) (prototypes.PrototypeModel, bool) {
+	var best prototypes.PrototypeModel
+	bestSpecificity := -1
+	for id := range r.store {
+		m, ok := r.getIfAliveByID(id)
+		if !ok || !strings.EqualFold(m.Request.Method, method) || !m.Request.IsTemplatePath() {
+			continue
+		}
+		if _, specificity, matched := m.Request.MatchPath(urlPath); matched && (bestSpecificity < 0 || m.OutranksTemplate(specificity, best, bestSpecificity)) {
+			best, bestSpecificity = m, specificity
+		}
+	}
+	return best, bestSpecificity >= 0
+}
+
 func (r *InMemoryPrototypesRepository) SaveOrUpdate(cc *customctx.CustomContext, document prototypes.PrototypeModel) utils.Result[string] {
 	if document.Request.BodySchema != nil && document.Request.BodySchema.TypeSchema == "" {
 		document.Request.BodySchema = nil
 	}
 
-	existing := r.GetByPath(cc, document.Request.UrlPath, document.Request.Method)
-	if existing.Err != nil {
+	// solo coincidencia exacta: /v1/users/me no debe reemplazar a /v1/users/:id
+	r.mu.Lock()
+	existing, ok := r.getIfAliveByKey(document.Request.Method, document.Request.UrlPath)
+	r.mu.Unlock()
+	if !ok {
 		// nuevo
 		document.CreatedAt = time.Now()
 		document.UpdatedAt = time.Now()
@@ -333,9 +538,9 @@ func (r *InMemoryPrototypesRepository) SaveOrUpdate(cc *customctx.CustomContext,
 	}
 
 	// reemplazo preservando ID y CreatedAt
-	document.ID = existing.Data.ID
+	document.ID = existing.ID
 	if document.CreatedAt.IsZero() {
-		document.CreatedAt = existing.Data.CreatedAt
+		document.CreatedAt = existing.CreatedAt
 	}
 	document.UpdatedAt = time.Now()
 
@@ -0,0 +1,118 @@
+package inmemory
+
+import (
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"mocky/internal/db/mongo/prototypes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InMemoryPrototypeRevisionsRepository guarda el historial de cada prototype. El historial
+// de uno caducado o eliminado sigue disponible para restaurarlo hasta que pasa ttl sin
+// revisiones nuevas; de cada prototype se conservan solo las últimas maxRevisions.
+type InMemoryPrototypeRevisionsRepository struct {
+	mu           sync.RWMutex
+	byPrototype  map[string]*revisionHistory
+	ttl          time.Duration
+	maxRevisions int
+}
+
+// historial con TTL; las revisiones podadas dejan de existir pero la numeración sigue
+type revisionHistory struct {
+	revisions []prototypes.PrototypeRevisionModel
+	last      int
+	expiresAt time.Time
+}
+
+// NewInMemoryPrototypeRevisionsRepository crea el repo.
+// ttl: vida del historial desde su última revisión (si <=0 usa 24 h).
+// maxRevisions: revisiones que se conservan por prototype (si <=0 usa 50).
+func NewInMemoryPrototypeRevisionsRepository(ttl time.Duration, maxRevisions int) *InMemoryPrototypeRevisionsRepository {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	if maxRevisions <= 0 {
+		maxRevisions = 50
+	}
+	return &InMemoryPrototypeRevisionsRepository{
+		byPrototype:  make(map[string]*revisionHistory),
+		ttl:          ttl,
+		maxRevisions: maxRevisions,
+	}
+}
+
+// purgeExpired elimina los historiales caducados; requiere el lock de escritura
+func (r *InMemoryPrototypeRevisionsRepository) purgeExpired() {
+	now := time.Now()
+	for id, history := range r.byPrototype {
+		if now.After(history.expiresAt) {
+			delete(r.byPrototype, id)
+		}
+	}
+}
+
+func (r *InMemoryPrototypeRevisionsRepository) aliveHistory(prototypeID string) *revisionHistory {
+	history, ok := r.byPrototype[prototypeID]
+	if !ok || time.Now().After(history.expiresAt) {
+		return nil
+	}
+	return history
+}
+
+func (r *InMemoryPrototypeRevisionsRepository) Append(ctx context.Context, revision prototypes.PrototypeRevisionModel) utils.Result[prototypes.PrototypeRevisionModel] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.purgeExpired()
+
+	history, ok := r.byPrototype[revision.PrototypeID]
+	if !ok {
+		history = &revisionHistory{}
+		r.byPrototype[revision.PrototypeID] = history
+	}
+
+	history.last++
+	revision.ID = primitive.NewObjectID().Hex()
+	revision.Revision = history.last
+	revision.CreatedAt = time.Now()
+
+	history.revisions = append(history.revisions, revision)
+	if excess := len(history.revisions) - r.maxRevisions; excess > 0 {
+		history.revisions = append([]prototypes.PrototypeRevisionModel(nil), history.revisions[excess:]...)
+	}
+	history.expiresAt = revision.CreatedAt.Add(r.ttl)
+
+	return utils.Result[prototypes.PrototypeRevisionModel]{Data: revision}
+}
+
+func (r *InMemoryPrototypeRevisionsRepository) List(ctx context.Context, prototypeID string) utils.Result[[]prototypes.PrototypeRevisionListModel] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := []prototypes.PrototypeRevisionListModel{}
+	if history := r.aliveHistory(prototypeID); history != nil {
+		for _, revision := range history.revisions {
+			list = append(list, revision.ToList())
+		}
+	}
+	return utils.Result[[]prototypes.PrototypeRevisionListModel]{Data: list}
+}
+
+func (r *InMemoryPrototypeRevisionsRepository) FindRevision(ctx context.Context, prototypeID string, revision int) utils.Result[prototypes.PrototypeRevisionModel] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// las revisiones van en orden y sin huecos desde la más antigua que se conserva
+	if history := r.aliveHistory(prototypeID); history != nil && len(history.revisions) > 0 {
+		if idx := revision - history.revisions[0].Revision; idx >= 0 && idx < len(history.revisions) {
+			return utils.Result[prototypes.PrototypeRevisionModel]{Data: history.revisions[idx]}
+		}
+	}
+	return utils.Result[prototypes.PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "prototype "+prototypeID+" has no revision "+strconv.Itoa(revision), "inmemory.revisions.find")}
+}
@@ -0,0 +1,153 @@
+package inmemory
+
+import (
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"mocky/internal/db/mongo/variables"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// entrada con TTL
+type entry struct {
+	model     variables.VariableSetModel
+	expiresAt time.Time
+}
+
+type InMemoryVariablesRepository struct {
+	mu      sync.RWMutex
+	store   map[string]entry
+	byScope map[string]string
+	ttl     time.Duration
+}
+
+// NewInMemoryVariablesRepository crea un repo con TTL fijo por entrada.
+// ttl: tiempo de vida de cada registro (si <=0 usa 5 min).
+func NewInMemoryVariablesRepository(ttl time.Duration) *InMemoryVariablesRepository {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &InMemoryVariablesRepository{
+		store:   make(map[string]entry),
+		byScope: make(map[string]string),
+		ttl:     ttl,
+	}
+}
+
+// ===================== Helpers =====================
+
+func (r *InMemoryVariablesRepository) put(id string, m variables.VariableSetModel) {
+	r.store[id] = entry{
+		model:     m,
+		expiresAt: time.Now().Add(r.ttl),
+	}
+	r.byScope[m.Scope] = id
+}
+
+func (r *InMemoryVariablesRepository) getIfAliveByID(id string) (variables.VariableSetModel, bool) {
+	e, ok := r.store[id]
+	if !ok {
+		return variables.VariableSetModel{}, false
+	}
+	if time.Now().After(e.expiresAt) {
+		// caducado: limpiar
+		delete(r.byScope, e.model.Scope)
+		delete(r.store, id)
+		return variables.VariableSetModel{}, false
+	}
+	return e.model, true
+}
+
+func (r *InMemoryVariablesRepository) getIfAliveByScope(scope string) (variables.VariableSetModel, bool) {
+	id, ok := r.byScope[scope]
+	if !ok {
+		return variables.VariableSetModel{}, false
+	}
+	return r.getIfAliveByID(id)
+}
+
+// ================= Implementación RepositoryVariables =================
+
+func (r *InMemoryVariablesRepository) Find(ctx context.Context, id string) utils.Result[variables.VariableSetModel] {
+	r.mu.Lock() // Lock para poder purgar si expiró
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByID(id)
+	if !ok {
+		return utils.Result[variables.VariableSetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "no se encontraron las variables", "inmemory.find")}
+	}
+	return utils.Result[variables.VariableSetModel]{Data: m}
+}
+
+func (r *InMemoryVariablesRepository) FindAll(ctx context.Context) utils.Result[[]variables.VariableSetListModel] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]variables.VariableSetListModel, 0, len(r.store))
+	for id := range r.store {
+		m, ok := r.getIfAliveByID(id)
+		if !ok {
+			continue
+		}
+		list = append(list, variables.VariableSetListModel{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			Scope:     m.Scope,
+			Values:    m.Values,
+		})
+	}
+	return utils.Result[[]variables.VariableSetListModel]{Data: list}
+}
+
+func (r *InMemoryVariablesRepository) GetByScope(cc *customctx.CustomContext, scope string) utils.Result[variables.VariableSetModel] {
+	r.mu.Lock() // Lock para poder purgar si caducó
+	defer r.mu.Unlock()
+
+	m, ok := r.getIfAliveByScope(scope)
+	if !ok {
+		return utils.Result[variables.VariableSetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have variables with scope: "+scope, "inmemory.get_by_scope")}
+	}
+	return utils.Result[variables.VariableSetModel]{Data: m}
+}
+
+func (r *InMemoryVariablesRepository) SaveOrUpdate(cc *customctx.CustomContext, document variables.VariableSetModel) utils.Result[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	document.UpdatedAt = time.Now()
+
+	existing, ok := r.getIfAliveByScope(document.Scope)
+	if !ok {
+		// nuevo
+		document.ID = primitive.NewObjectID().Hex()
+		document.CreatedAt = time.Now()
+		r.put(document.ID, document)
+		return utils.Result[string]{Data: document.ID}
+	}
+
+	// reemplazo preservando ID y CreatedAt
+	document.ID = existing.ID
+	document.CreatedAt = existing.CreatedAt
+	r.put(document.ID, document)
+
+	return utils.Result[string]{Data: document.ID}
+}
+
+func (r *InMemoryVariablesRepository) DeleteByScope(cc *customctx.CustomContext, scope string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byScope[scope]
+	if !ok {
+		return cerrs.NewCustomError(http.StatusNotFound, "no se encontraron las variables", "inmemory.delete_by_scope")
+	}
+	delete(r.byScope, scope)
+	delete(r.store, id)
+	return nil
+}
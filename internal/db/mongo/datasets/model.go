@@ -0,0 +1,31 @@
+package datasets
+
+import (
+	"time"
+)
+
+// DatasetModel guarda los registros subidos por /v1/datasets (CSV o JSON).
+type DatasetModel struct {
+	ID        string           `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time        `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt" bson:"updatedAt"`
+	Name      string           `json:"name" bson:"name"`
+	Size      int              `json:"size" bson:"size"`
+	Records   []map[string]any `json:"records" bson:"records"`
+}
+
+func (d DatasetModel) GetID() string {
+	return d.ID
+}
+
+type DatasetListModel struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	Name      string    `json:"name" bson:"name"`
+	Size      int       `json:"size" bson:"size"`
+}
+
+func (d DatasetListModel) GetID() string {
+	return d.ID
+}
@@ -0,0 +1,79 @@
+package datasets
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	ppmongo "common/infrastructure/db/ppmongo"
+	"common/utils"
+	"common/utils/cerrs"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type DatasetsMongoRepository struct {
+	*ppmongo.MongoRepository[DatasetModel, DatasetListModel]
+}
+
+func NewDatasetsMongoRepository(uri string, dbName string, collectionName string) *DatasetsMongoRepository {
+	return &DatasetsMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[DatasetModel, DatasetListModel](uri, dbName, collectionName),
+	}
+}
+
+func (m *DatasetsMongoRepository) GetByName(cc *customctx.CustomContext, name string) utils.Result[DatasetModel] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo GetByName name=%s", name)
+
+	var out DatasetModel
+	err := m.Collection.FindOne(cc.Context(), bson.M{"name": name}).Decode(&out)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.Result[DatasetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have dataset with name: "+name, "mongo.get_by_name")}
+		}
+		return utils.Result[DatasetModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.get_by_name")}
+	}
+
+	return utils.Result[DatasetModel]{Data: out}
+}
+
+func (m *DatasetsMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, document DatasetModel) utils.Result[string] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo SaveOrUpdate dataset=%s", document.Name)
+
+	document.Size = len(document.Records)
+
+	existing := m.GetByName(cc, document.Name)
+
+	// If the dataset does not exist, we save it
+	if existing.Err != nil {
+		document.CreatedAt = time.Now()
+		document.UpdatedAt = time.Now()
+		return m.MongoRepository.Save(cc.Context(), document)
+	}
+
+	// If the dataset exists, we replace it keeping its ID
+	err := m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+	if err != nil {
+		return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.save_or_update")}
+	}
+
+	document.CreatedAt = existing.Data.CreatedAt
+	document.UpdatedAt = time.Now()
+
+	return m.MongoRepository.SaveWithID(cc.Context(), existing.Data.ID, document)
+}
+
+func (m *DatasetsMongoRepository) DeleteByName(cc *customctx.CustomContext, name string) error {
+	existing := m.GetByName(cc, name)
+	if existing.Err != nil {
+		return existing.Err
+	}
+
+	return m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+}
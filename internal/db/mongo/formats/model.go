@@ -0,0 +1,33 @@
+package formats
+
+import (
+	"time"
+)
+
+// FormatModel es un format registrado por el usuario (p. ej. "rfc", "clabe") que las
+// properties del bodySchema pueden usar en "format". Se valida con Pattern, Checksum o ambos.
+type FormatModel struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	Name      string    `json:"name" bson:"name"`
+	Pattern   string    `json:"pattern,omitempty" bson:"pattern,omitempty"`
+	Checksum  string    `json:"checksum,omitempty" bson:"checksum,omitempty"`
+}
+
+func (f FormatModel) GetID() string {
+	return f.ID
+}
+
+type FormatListModel struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	Name      string    `json:"name" bson:"name"`
+	Pattern   string    `json:"pattern,omitempty" bson:"pattern,omitempty"`
+	Checksum  string    `json:"checksum,omitempty" bson:"checksum,omitempty"`
+}
+
+func (f FormatListModel) GetID() string {
+	return f.ID
+}
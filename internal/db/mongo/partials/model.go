@@ -0,0 +1,29 @@
+package partials
+
+import (
+	"time"
+)
+
+// PartialModel es un fragmento de respuesta reutilizable ({"$partial": "envelope"} o {{> envelope}}).
+type PartialModel struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	Name      string    `json:"name" bson:"name"`
+	Body      any       `json:"body" bson:"body"`
+}
+
+func (p PartialModel) GetID() string {
+	return p.ID
+}
+
+type PartialListModel struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	Name      string    `json:"name" bson:"name"`
+}
+
+func (p PartialListModel) GetID() string {
+	return p.ID
+}
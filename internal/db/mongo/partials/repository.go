@@ -0,0 +1,77 @@
+package partials
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	ppmongo "common/infrastructure/db/ppmongo"
+	"common/utils"
+	"common/utils/cerrs"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type PartialsMongoRepository struct {
+	*ppmongo.MongoRepository[PartialModel, PartialListModel]
+}
+
+func NewPartialsMongoRepository(uri string, dbName string, collectionName string) *PartialsMongoRepository {
+	return &PartialsMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[PartialModel, PartialListModel](uri, dbName, collectionName),
+	}
+}
+
+func (m *PartialsMongoRepository) GetByName(cc *customctx.CustomContext, name string) utils.Result[PartialModel] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo GetByName name=%s", name)
+
+	var out PartialModel
+	err := m.Collection.FindOne(cc.Context(), bson.M{"name": name}).Decode(&out)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.Result[PartialModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have partial with name: "+name, "mongo.get_by_name")}
+		}
+		return utils.Result[PartialModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.get_by_name")}
+	}
+
+	return utils.Result[PartialModel]{Data: out}
+}
+
+func (m *PartialsMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, document PartialModel) utils.Result[string] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo SaveOrUpdate partial=%s", document.Name)
+
+	existing := m.GetByName(cc, document.Name)
+
+	// If the partial does not exist, we save it
+	if existing.Err != nil {
+		document.CreatedAt = time.Now()
+		document.UpdatedAt = time.Now()
+		return m.MongoRepository.Save(cc.Context(), document)
+	}
+
+	// If the partial exists, we replace it keeping its ID
+	err := m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+	if err != nil {
+		return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.save_or_update")}
+	}
+
+	document.CreatedAt = existing.Data.CreatedAt
+	document.UpdatedAt = time.Now()
+
+	return m.MongoRepository.SaveWithID(cc.Context(), existing.Data.ID, document)
+}
+
+func (m *PartialsMongoRepository) DeleteByName(cc *customctx.CustomContext, name string) error {
+	existing := m.GetByName(cc, name)
+	if existing.Err != nil {
+		return existing.Err
+	}
+
+	return m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+}
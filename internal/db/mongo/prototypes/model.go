@@ -13,18 +13,37 @@ type PrototypeModel struct {
 	Request   entities.RequestEntity  `json:"request" bson:"request"`
 	Response  entities.ResponseEntity `json:"response" bson:"response"`
 	Name      string                  `json:"name" bson:"name"`
+	Group     string                  `json:"group,omitempty" bson:"group,omitempty"`
+	Overrides []string                `json:"overrides,omitempty" bson:"overrides,omitempty"`
 }
 
 func (g PrototypeModel) GetID() string {
 	return g.ID
 }
 
+// OutranksTemplate decide entre dos prototypes con path dinámico que coinciden con el mismo
+// path: gana el de más segmentos literales; a igual specificity, el urlPath menor en orden
+// lexicográfico y luego el más antiguo, así el elegido no depende del orden de búsqueda.
+func (g PrototypeModel) OutranksTemplate(specificity int, best PrototypeModel, bestSpecificity int) bool {
+	if specificity != bestSpecificity {
+		return specificity > bestSpecificity
+	}
+	if g.Request.UrlPath != best.Request.UrlPath {
+		return g.Request.UrlPath < best.Request.UrlPath
+	}
+	if !g.CreatedAt.Equal(best.CreatedAt) {
+		return g.CreatedAt.Before(best.CreatedAt)
+	}
+	return g.ID < best.ID
+}
+
 type PrototypeListModel struct {
 	ID        string          `json:"id" bson:"_id,omitempty"`
 	CreatedAt time.Time       `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time       `json:"updatedAt" bson:"updatedAt"`
 	Request   RequestListView `json:"request" bson:"request"`
 	Name      string          `json:"name" bson:"name"`
+	Group     string          `json:"group,omitempty" bson:"group,omitempty"`
 }
 
 func (g PrototypeListModel) GetID() string {
@@ -30,6 +30,15 @@ func (m *PrototypesMongoRepository) GetByPath(cc *customctx.CustomContext, urlPa
 	entry := logger.FromContext(cc.Context())
 	entry.Infof("Mongo GetByPath urlPath=%s", urlPath)
 
+	result := m.getByExactPath(cc, urlPath, method)
+	if result.Err != nil && result.Err.GetCode() == http.StatusNotFound {
+		return m.getByTemplatePath(cc, urlPath, method)
+	}
+
+	return result
+}
+
+func (m *PrototypesMongoRepository) getByExactPath(cc *customctx.CustomContext, urlPath string, method string) utils.Result[PrototypeModel] {
 	var out PrototypeModel
 	filter := bson.M{"request.urlpath": urlPath, "request.method": method}
 
@@ -44,6 +53,36 @@ func (m *PrototypesMongoRepository) GetByPath(cc *customctx.CustomContext, urlPa
 	return utils.Result[PrototypeModel]{Data: out}
 }
 
+// getByTemplatePath busca entre los prototipos del método con path dinámico (/v1/users/:id) el más específico
+func (m *PrototypesMongoRepository) getByTemplatePath(cc *customctx.CustomContext, urlPath string, method string) utils.Result[PrototypeModel] {
+	cursor, err := m.Collection.Find(cc.Context(), bson.M{"request.method": method})
+	if err != nil {
+		return utils.Result[PrototypeModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.get_by_template_path")}
+	}
+
+	var candidates []PrototypeModel
+	if err := cursor.All(cc.Context(), &candidates); err != nil {
+		return utils.Result[PrototypeModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.get_by_template_path")}
+	}
+
+	var best PrototypeModel
+	bestSpecificity := -1
+	for _, candidate := range candidates {
+		if !candidate.Request.IsTemplatePath() {
+			continue
+		}
+		if _, specificity, matched := candidate.Request.MatchPath(urlPath); matched && (bestSpecificity < 0 || candidate.OutranksTemplate(specificity, best, bestSpecificity)) {
+			best, bestSpecificity = candidate, specificity
+		}
+	}
+
+	if bestSpecificity < 0 {
+		return utils.Result[PrototypeModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have prototype for this path: "+urlPath+" and method: "+method, "mongo.get_by_path")}
+	}
+
+	return utils.Result[PrototypeModel]{Data: best}
+}
+
 func (m *PrototypesMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, document PrototypeModel) utils.Result[string] {
 	entry := logger.FromContext(cc.Context())
 	entry.Infof("Mongo SaveOrUpdate document=%v", document)
@@ -52,7 +91,8 @@ func (m *PrototypesMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, do
 		document.Request.BodySchema = nil
 	}
 
-	prototypeModel := m.GetByPath(cc, document.Request.UrlPath, document.Request.Method)
+	// solo coincidencia exacta: /v1/users/me no debe reemplazar a /v1/users/:id
+	prototypeModel := m.getByExactPath(cc, document.Request.UrlPath, document.Request.Method)
 
 	// If the prototype does not exist, we save it
 	if prototypeModel.Err != nil {
@@ -68,6 +108,7 @@ func (m *PrototypesMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, do
 		return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.save_or_update")}
 	}
 
+	document.CreatedAt = prototypeModel.Data.CreatedAt
 	document.UpdatedAt = time.Now()
 
 	newPrototype := m.MongoRepository.SaveWithID(cc.Context(), prototypeModel.Data.ID, document)
@@ -77,3 +118,11 @@ func (m *PrototypesMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, do
 
 	return utils.Result[string]{Data: newPrototype.Data}
 }
+
+func (m *PrototypesMongoRepository) DeleteAll(cc *customctx.CustomContext) utils.Result[int64] {
+	result, err := m.Collection.DeleteMany(cc.Context(), bson.M{})
+	if err != nil {
+		return utils.Result[int64]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.delete_all")}
+	}
+	return utils.Result[int64]{Data: result.DeletedCount}
+}
@@ -0,0 +1,56 @@
+package prototypes
+
+import "time"
+
+// Acciones que dejan una revisión en el historial de un prototype
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionImport  = "import"
+	RevisionRestore = "restore"
+	RevisionDelete  = "delete"
+)
+
+// PrototypeRevisionModel es una foto completa del prototype después de un cambio. Las
+// revisiones no se modifican ni se borran: restaurar una versión agrega una nueva.
+type PrototypeRevisionModel struct {
+	ID           string         `json:"id" bson:"_id,omitempty"`
+	PrototypeID  string         `json:"prototypeId" bson:"prototypeId"`
+	Revision     int            `json:"revision" bson:"revision"`
+	Action       string         `json:"action" bson:"action"`
+	Author       string         `json:"author" bson:"author"`
+	CreatedAt    time.Time      `json:"createdAt" bson:"createdAt"`
+	RestoredFrom int            `json:"restoredFrom,omitempty" bson:"restoredFrom,omitempty"`
+	Snapshot     PrototypeModel `json:"snapshot" bson:"snapshot"`
+}
+
+func (g PrototypeRevisionModel) GetID() string {
+	return g.ID
+}
+
+// PrototypeRevisionListModel es la revisión sin la foto, para el listado
+type PrototypeRevisionListModel struct {
+	ID           string    `json:"id" bson:"_id,omitempty"`
+	PrototypeID  string    `json:"prototypeId" bson:"prototypeId"`
+	Revision     int       `json:"revision" bson:"revision"`
+	Action       string    `json:"action" bson:"action"`
+	Author       string    `json:"author" bson:"author"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	RestoredFrom int       `json:"restoredFrom,omitempty" bson:"restoredFrom,omitempty"`
+}
+
+func (g PrototypeRevisionListModel) GetID() string {
+	return g.ID
+}
+
+func (g PrototypeRevisionModel) ToList() PrototypeRevisionListModel {
+	return PrototypeRevisionListModel{
+		ID:           g.ID,
+		PrototypeID:  g.PrototypeID,
+		Revision:     g.Revision,
+		Action:       g.Action,
+		Author:       g.Author,
+		CreatedAt:    g.CreatedAt,
+		RestoredFrom: g.RestoredFrom,
+	}
+}
@@ -0,0 +1,110 @@
+package prototypes
+
+import (
+	ppmongo "common/infrastructure/db/ppmongo"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// intentos de Append cuando dos escrituras toman el mismo número de revisión
+const revisionAppendAttempts = 5
+
+type PrototypeRevisionsMongoRepository struct {
+	*ppmongo.MongoRepository[PrototypeRevisionModel, PrototypeRevisionListModel]
+}
+
+// NewPrototypeRevisionsMongoRepository crea el repositorio y el índice único prototypeId+revision,
+// que es lo que impide que dos escrituras concurrentes dejen el mismo número de revisión
+func NewPrototypeRevisionsMongoRepository(uri string, dbName string, collectionName string) *PrototypeRevisionsMongoRepository {
+	repository := &PrototypeRevisionsMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[PrototypeRevisionModel, PrototypeRevisionListModel](uri, dbName, collectionName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := repository.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "prototypeId", Value: 1}, {Key: "revision", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("Mongo revisions index: %s", err.Error())
+	}
+
+	return repository
+}
+
+func (m *PrototypeRevisionsMongoRepository) Append(ctx context.Context, revision PrototypeRevisionModel) utils.Result[PrototypeRevisionModel] {
+	revision.ID = ""
+	revision.CreatedAt = time.Now()
+
+	for attempt := 0; attempt < revisionAppendAttempts; attempt++ {
+		last, err := m.lastRevision(ctx, revision.PrototypeID)
+		if err != nil {
+			return utils.Result[PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.revisions.append")}
+		}
+		revision.Revision = last + 1
+
+		result, err := m.Collection.InsertOne(ctx, revision)
+		if err == nil {
+			if insertedID, ok := result.InsertedID.(primitive.ObjectID); ok {
+				revision.ID = insertedID.Hex()
+			}
+			return utils.Result[PrototypeRevisionModel]{Data: revision}
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return utils.Result[PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.revisions.append")}
+		}
+	}
+
+	return utils.Result[PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusConflict, "could not reserve a revision number for prototype "+revision.PrototypeID, "mongo.revisions.append")}
+}
+
+func (m *PrototypeRevisionsMongoRepository) lastRevision(ctx context.Context, prototypeID string) (int, error) {
+	var last PrototypeRevisionListModel
+	opts := options.FindOne().SetSort(bson.D{{Key: "revision", Value: -1}}).SetProjection(bson.M{"snapshot": 0})
+	err := m.Collection.FindOne(ctx, bson.M{"prototypeId": prototypeID}, opts).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return last.Revision, nil
+}
+
+func (m *PrototypeRevisionsMongoRepository) List(ctx context.Context, prototypeID string) utils.Result[[]PrototypeRevisionListModel] {
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: 1}}).SetProjection(bson.M{"snapshot": 0})
+	cursor, err := m.Collection.Find(ctx, bson.M{"prototypeId": prototypeID}, opts)
+	if err != nil {
+		return utils.Result[[]PrototypeRevisionListModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.revisions.list")}
+	}
+
+	revisions := []PrototypeRevisionListModel{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return utils.Result[[]PrototypeRevisionListModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.revisions.list")}
+	}
+	return utils.Result[[]PrototypeRevisionListModel]{Data: revisions}
+}
+
+func (m *PrototypeRevisionsMongoRepository) FindRevision(ctx context.Context, prototypeID string, revision int) utils.Result[PrototypeRevisionModel] {
+	var out PrototypeRevisionModel
+	err := m.Collection.FindOne(ctx, bson.M{"prototypeId": prototypeID, "revision": revision}).Decode(&out)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.Result[PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "prototype "+prototypeID+" has no revision "+strconv.Itoa(revision), "mongo.revisions.find")}
+		}
+		return utils.Result[PrototypeRevisionModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.revisions.find")}
+	}
+	return utils.Result[PrototypeRevisionModel]{Data: out}
+}
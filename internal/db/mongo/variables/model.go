@@ -0,0 +1,34 @@
+package variables
+
+import (
+	"time"
+)
+
+// GlobalScope es el scope de las variables visibles para todos los prototypes;
+// cualquier otro scope corresponde al "group" de un prototype.
+const GlobalScope = "global"
+
+// VariableSetModel agrupa las variables de un scope ({{vars.base_url}})
+type VariableSetModel struct {
+	ID        string         `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt" bson:"updatedAt"`
+	Scope     string         `json:"scope" bson:"scope"`
+	Values    map[string]any `json:"values" bson:"values"`
+}
+
+func (v VariableSetModel) GetID() string {
+	return v.ID
+}
+
+type VariableSetListModel struct {
+	ID        string         `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt" bson:"updatedAt"`
+	Scope     string         `json:"scope" bson:"scope"`
+	Values    map[string]any `json:"values" bson:"values"`
+}
+
+func (v VariableSetListModel) GetID() string {
+	return v.ID
+}
@@ -0,0 +1,77 @@
+package variables
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	ppmongo "common/infrastructure/db/ppmongo"
+	"common/utils"
+	"common/utils/cerrs"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type VariablesMongoRepository struct {
+	*ppmongo.MongoRepository[VariableSetModel, VariableSetListModel]
+}
+
+func NewVariablesMongoRepository(uri string, dbName string, collectionName string) *VariablesMongoRepository {
+	return &VariablesMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[VariableSetModel, VariableSetListModel](uri, dbName, collectionName),
+	}
+}
+
+func (m *VariablesMongoRepository) GetByScope(cc *customctx.CustomContext, scope string) utils.Result[VariableSetModel] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo GetByScope scope=%s", scope)
+
+	var out VariableSetModel
+	err := m.Collection.FindOne(cc.Context(), bson.M{"scope": scope}).Decode(&out)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.Result[VariableSetModel]{Err: cerrs.NewCustomError(http.StatusNotFound, "dont have variables with scope: "+scope, "mongo.get_by_scope")}
+		}
+		return utils.Result[VariableSetModel]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.get_by_scope")}
+	}
+
+	return utils.Result[VariableSetModel]{Data: out}
+}
+
+func (m *VariablesMongoRepository) SaveOrUpdate(cc *customctx.CustomContext, document VariableSetModel) utils.Result[string] {
+	entry := logger.FromContext(cc.Context())
+	entry.Infof("Mongo SaveOrUpdate scope=%s", document.Scope)
+
+	existing := m.GetByScope(cc, document.Scope)
+
+	// If the scope does not exist, we save it
+	if existing.Err != nil {
+		document.CreatedAt = time.Now()
+		document.UpdatedAt = time.Now()
+		return m.MongoRepository.Save(cc.Context(), document)
+	}
+
+	// If the scope exists, we replace it keeping its ID
+	err := m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+	if err != nil {
+		return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.save_or_update")}
+	}
+
+	document.CreatedAt = existing.Data.CreatedAt
+	document.UpdatedAt = time.Now()
+
+	return m.MongoRepository.SaveWithID(cc.Context(), existing.Data.ID, document)
+}
+
+func (m *VariablesMongoRepository) DeleteByScope(cc *customctx.CustomContext, scope string) error {
+	existing := m.GetByScope(cc, scope)
+	if existing.Err != nil {
+		return existing.Err
+	}
+
+	return m.MongoRepository.Delete(cc.Context(), existing.Data.ID)
+}